@@ -0,0 +1,293 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/authorization"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// AuditedEntityEngine passes calls to EntityEngine and appends an Entry for each call to Log.
+// When the Entry cannot be appended, the result is withheld and an error returned instead.
+type AuditedEntityEngine struct {
+	Attributes   string // How search attributes are written: one of the Attributes* constants; AttributesHash if empty
+	EntityEngine senzingchatservice.EntityEngine
+	Log          *Log
+}
+
+type entityDocument struct {
+	RelatedEntities []entityReference `json:"RELATED_ENTITIES"`
+	ResolvedEntity  entityReference   `json:"RESOLVED_ENTITY"`
+}
+
+type entityReference struct {
+	EntityID int64 `json:"ENTITY_ID"`
+}
+
+type searchDocument struct {
+	ResolvedEntities []searchResult `json:"RESOLVED_ENTITIES"`
+}
+
+type searchResult struct {
+	Entity entityDocument `json:"ENTITY"`
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID calls the wrapped EntityEngine and audits the entity and its related entities.
+func (engine *AuditedEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	result, err := engine.EntityEngine.GetEntityByEntityID(ctx, entityID)
+	entry := newEntry(ctx, OperationGetEntityByEntityID, err)
+	entry.EntityID = entityID
+
+	if err == nil {
+		entry.EntityIDs = entityDocumentIDs(result)
+	}
+
+	return engine.audit(entry, result, err)
+}
+
+// HowEntityByEntityID calls the wrapped EntityEngine and audits the entity.
+func (engine *AuditedEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	result, err := engine.EntityEngine.HowEntityByEntityID(ctx, entityID)
+	entry := newEntry(ctx, OperationHowEntityByEntityID, err)
+	entry.EntityID = entityID
+
+	if err == nil {
+		entry.EntityIDs = []int64{entityID}
+	}
+
+	return engine.audit(entry, result, err)
+}
+
+// SearchByAttributes calls the wrapped EntityEngine and audits the attributes and the entities found.
+func (engine *AuditedEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	result, err := engine.EntityEngine.SearchByAttributes(ctx, attributes)
+	entry := newEntry(ctx, OperationSearchByAttributes, err)
+	entry.Attributes = redactAttributes(attributes, engine.Attributes, engine.Log.key)
+
+	if err == nil {
+		entry.EntityIDs = searchDocumentIDs(result)
+	}
+
+	return engine.audit(entry, result, err)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The CheckAttributes function checks the name of a way to write search attributes.
+
+Input
+  - attributes: One of the Attributes* constants, or empty for AttributesHash.
+
+Output
+  - An error if attributes is not one of the Attributes* constants.
+*/
+func CheckAttributes(attributes string) error {
+	if len(attributes) == 0 || slices.Contains(attributeModes, attributes) {
+		return nil
+	}
+
+	return wraperror.Errorf(errForPackage, "unknown audit attributes %q; use one of %s",
+		attributes, strings.Join(attributeModes, ", "))
+}
+
+/*
+The ConversationIDFromContext function returns the conversation ID stored in a context.
+
+Input
+  - ctx: A context, usually of an HTTP request.
+
+Output
+  - The conversation ID, or an empty string if the call is not part of a conversation.
+*/
+func ConversationIDFromContext(ctx context.Context) string {
+	result, _ := ctx.Value(contextKey{}).(string)
+
+	return result
+}
+
+/*
+The HashValue function hashes a search attribute value the way AttributesHash writes it to the Log.
+An auditor holding the Log's key can use it to find searches for a known value.
+
+Input
+  - key: The key of the Log, see LoadKey.
+  - value: A search attribute value, e.g. "Robert Smith".
+
+Output
+  - "hmac-sha256:" followed by the hex HMAC-SHA256 of value, under a key derived from key.
+*/
+func HashValue(key []byte, value string) string {
+	mac := hmac.New(sha256.New, attributeKey(key))
+	_, _ = mac.Write([]byte(value))
+
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+/*
+The NewContext function returns a copy of a context that carries a conversation ID.
+
+Input
+  - ctx: The parent context.
+  - conversationID: Identifies a chat conversation.  It is truncated to 256 bytes.
+
+Output
+  - A context from which ConversationIDFromContext returns conversationID.
+*/
+func NewContext(ctx context.Context, conversationID string) context.Context {
+	if len(conversationID) > maxConversationIDLength {
+		conversationID = strings.ToValidUTF8(conversationID[:maxConversationIDLength], "")
+	}
+
+	return context.WithValue(ctx, contextKey{}, conversationID)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *AuditedEntityEngine) audit(entry *Entry, result string, err error) (string, error) {
+	auditErr := engine.Log.Append(entry)
+	if auditErr != nil {
+		return "", wraperror.Errorf(auditErr, "audit %s", entry.Operation)
+	}
+
+	return result, err //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The key of attribute hashes, derived from the Log's key so that neither hash can stand in for the other.
+func attributeKey(key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(attributeKeyLabel))
+
+	return mac.Sum(nil)
+}
+
+func newEntry(ctx context.Context, operation string, err error) *Entry {
+	result := &Entry{ //nolint:exhaustruct
+		ConversationID: ConversationIDFromContext(ctx),
+		EntityIDs:      []int64{},
+		Operation:      operation,
+	}
+
+	if caller, ok := identity.FromContext(ctx); ok {
+		result.Principal = authorization.Principal(caller)
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// The IDs of the resolved entity and its related entities.  A document that cannot be read yields no IDs.
+func entityDocumentIDs(entityJSON string) []int64 {
+	var document entityDocument
+
+	result := []int64{}
+
+	if json.Unmarshal([]byte(entityJSON), &document) != nil {
+		return result
+	}
+
+	if document.ResolvedEntity.EntityID != 0 {
+		result = append(result, document.ResolvedEntity.EntityID)
+	}
+
+	for _, relatedEntity := range document.RelatedEntities {
+		result = append(result, relatedEntity.EntityID)
+	}
+
+	return result
+}
+
+func searchDocumentIDs(searchJSON string) []int64 {
+	var document searchDocument
+
+	result := []int64{}
+
+	if json.Unmarshal([]byte(searchJSON), &document) != nil {
+		return result
+	}
+
+	for _, resolvedEntity := range document.ResolvedEntities {
+		result = append(result, resolvedEntity.Entity.ResolvedEntity.EntityID)
+	}
+
+	return result
+}
+
+// Search attributes as written to the Log.  Attributes that are not JSON are treated as one value.
+func redactAttributes(attributes string, mode string, key []byte) json.RawMessage {
+	var document any
+
+	decoder := json.NewDecoder(strings.NewReader(attributes))
+	decoder.UseNumber()
+
+	if decoder.Decode(&document) != nil {
+		document = attributes
+	}
+
+	if mode != AttributesVerbatim {
+		document = redactValues(document, mode, key)
+	}
+
+	result, err := json.Marshal(document)
+	if err != nil {
+		return json.RawMessage(`"` + RedactedValue + `"`)
+	}
+
+	return result
+}
+
+// Replace every value in a document, keeping its structure and member names.
+func redactValues(value any, mode string, key []byte) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+		for name, member := range typedValue {
+			result[name] = redactValues(member, mode, key)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(typedValue))
+		for index, member := range typedValue {
+			result[index] = redactValues(member, mode, key)
+		}
+
+		return result
+	case nil:
+		return nil
+	default:
+		if mode == AttributesRedact {
+			return RedactedValue
+		}
+
+		return HashValue(key, fmt.Sprint(typedValue))
+	}
+}
@@ -0,0 +1,323 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/audit"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/senzing-garage/serve-chat/internal/enginetest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	entityJSON = `{
+		"RESOLVED_ENTITY": {"ENTITY_ID": 100001, "ENTITY_NAME": "Robert Smith"},
+		"RELATED_ENTITIES": [{"ENTITY_ID": 100002}, {"ENTITY_ID": 100003}]
+	}`
+	searchAttributes = `{"NAME_FULL": "Robert Smith", "DATE_OF_BIRTH": "1985-02-11"}`
+	searchJSON       = `{
+		"RESOLVED_ENTITIES": [
+			{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 100001}}},
+			{"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 100004}}}
+		]
+	}`
+)
+
+var (
+	auditKey    = []byte("0123456789abcdef0123456789abcdef")
+	errNotFound = errors.New("entity not found")
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAuditedEntityEngine_GetEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	filename := filepath.Join(test.TempDir(), "audit.jsonl")
+	engine := newAuditedEntityEngine(test, filename, audit.AttributesHash)
+
+	result, err := engine.GetEntityByEntityID(callerContext(test, "analyst", "conversation-1"), 100001)
+	require.NoError(test, err)
+	require.Equal(test, entityJSON, result)
+
+	_, err = engine.GetEntityByEntityID(callerContext(test, "analyst", ""), 0)
+	require.Error(test, err)
+
+	entries := readEntries(test, filename)
+	require.Len(test, entries, 2)
+	require.Equal(test, audit.OperationGetEntityByEntityID, entries[0].Operation)
+	require.Equal(test, "api-key:analyst", entries[0].Principal)
+	require.Equal(test, "conversation-1", entries[0].ConversationID)
+	require.Equal(test, int64(100001), entries[0].EntityID)
+	require.Equal(test, []int64{100001, 100002, 100003}, entries[0].EntityIDs)
+	require.Empty(test, entries[1].ConversationID)
+	require.Empty(test, entries[1].EntityIDs)
+	require.NotEmpty(test, entries[1].Error)
+}
+
+func TestAuditedEntityEngine_HowEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	filename := filepath.Join(test.TempDir(), "audit.jsonl")
+	engine := newAuditedEntityEngine(test, filename, audit.AttributesHash)
+
+	_, err := engine.HowEntityByEntityID(test.Context(), 100001)
+	require.NoError(test, err)
+
+	entries := readEntries(test, filename)
+	require.Len(test, entries, 1)
+	require.Equal(test, audit.OperationHowEntityByEntityID, entries[0].Operation)
+	require.Empty(test, entries[0].Principal)
+	require.Equal(test, []int64{100001}, entries[0].EntityIDs)
+}
+
+func TestAuditedEntityEngine_SearchByAttributes(test *testing.T) {
+	test.Parallel()
+
+	for attributes, expected := range map[string]string{
+		audit.AttributesHash: `{
+			"DATE_OF_BIRTH": "` + audit.HashValue(auditKey, "1985-02-11") + `",
+			"NAME_FULL": "` + audit.HashValue(auditKey, "Robert Smith") + `"
+		}`,
+		audit.AttributesRedact:   `{"DATE_OF_BIRTH": "[REDACTED]", "NAME_FULL": "[REDACTED]"}`,
+		audit.AttributesVerbatim: searchAttributes,
+	} {
+		filename := filepath.Join(test.TempDir(), "audit.jsonl")
+		engine := newAuditedEntityEngine(test, filename, attributes)
+
+		result, err := engine.SearchByAttributes(callerContext(test, "analyst", "conversation-1"), searchAttributes)
+		require.NoError(test, err)
+		require.Equal(test, searchJSON, result)
+
+		entries := readEntries(test, filename)
+		require.Len(test, entries, 1)
+		require.Equal(test, audit.OperationSearchByAttributes, entries[0].Operation)
+		require.JSONEq(test, expected, string(entries[0].Attributes), attributes)
+		require.Equal(test, []int64{100001, 100004}, entries[0].EntityIDs)
+	}
+}
+
+func TestAuditedEntityEngine_SearchByAttributes_withheld(test *testing.T) {
+	test.Parallel()
+	filename := filepath.Join(test.TempDir(), "audit.jsonl")
+	engine := newAuditedEntityEngine(test, filename, audit.AttributesHash)
+	require.NoError(test, engine.Log.Close())
+
+	result, err := engine.SearchByAttributes(test.Context(), searchAttributes)
+	require.Error(test, err)
+	require.Empty(test, result)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestCheckAttributes(test *testing.T) {
+	test.Parallel()
+	require.NoError(test, audit.CheckAttributes(""))
+	require.NoError(test, audit.CheckAttributes(audit.AttributesRedact))
+	require.Error(test, audit.CheckAttributes("encrypt"))
+}
+
+func TestHashValue(test *testing.T) {
+	test.Parallel()
+	expected := "hmac-sha256:292ca66af1cf01f9fde0565edc251eefbd4c41fd9d58395a5646c52b33d68c67"
+	require.Equal(test, expected, audit.HashValue(auditKey, "abc"))
+
+	// Without the key, the hash cannot be recomputed.
+
+	otherKey := bytes.Repeat([]byte("k"), 32)
+	require.NotEqual(test, audit.HashValue(auditKey, "abc"), audit.HashValue(otherKey, "abc"))
+}
+
+func TestLoadKey(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+
+	keyFile := filepath.Join(directory, "audit.key")
+	require.NoError(test, os.WriteFile(keyFile, append(auditKey, '\n'), 0o600))
+	key, err := audit.LoadKey(keyFile)
+	require.NoError(test, err)
+	require.Equal(test, auditKey, key)
+
+	shortKeyFile := filepath.Join(directory, "short.key")
+	require.NoError(test, os.WriteFile(shortKeyFile, []byte("secret\n"), 0o600))
+	_, err = audit.LoadKey(shortKeyFile)
+	require.Error(test, err)
+
+	_, err = audit.LoadKey(filepath.Join(directory, "missing.key"))
+	require.Error(test, err)
+}
+
+func TestNewContext(test *testing.T) {
+	test.Parallel()
+	require.Empty(test, audit.ConversationIDFromContext(test.Context()))
+
+	ctx := audit.NewContext(test.Context(), strings.Repeat("c", 1000))
+	require.Len(test, audit.ConversationIDFromContext(ctx), 256)
+}
+
+func TestOpenLog_continue(test *testing.T) {
+	test.Parallel()
+	filename := filepath.Join(test.TempDir(), "audit.jsonl")
+
+	for range 2 {
+		engine := newAuditedEntityEngine(test, filename, audit.AttributesHash)
+		_, err := engine.SearchByAttributes(test.Context(), searchAttributes)
+		require.NoError(test, err)
+		require.NoError(test, engine.Log.Close())
+	}
+
+	file, err := os.Open(filename)
+	require.NoError(test, err)
+
+	defer file.Close()
+
+	lastEntry, err := audit.Verify(file, auditKey)
+	require.NoError(test, err)
+	require.Equal(test, int64(2), lastEntry.Sequence)
+}
+
+func TestOpenLog_tampered(test *testing.T) {
+	test.Parallel()
+	filename := writeLog(test)
+	content, err := os.ReadFile(filename)
+	require.NoError(test, err)
+	require.NoError(test, os.WriteFile(filename, bytes.Replace(content, []byte("analyst"), []byte("auditor"), 1), 0o600))
+
+	_, err = audit.OpenLog(filename, auditKey)
+	require.Error(test, err)
+}
+
+func TestVerify(test *testing.T) {
+	test.Parallel()
+	content, err := os.ReadFile(writeLog(test))
+	require.NoError(test, err)
+
+	lastEntry, err := audit.Verify(bytes.NewReader(content), auditKey)
+	require.NoError(test, err)
+	require.Equal(test, int64(3), lastEntry.Sequence)
+
+	lastEntry, err = audit.Verify(strings.NewReader(""), auditKey)
+	require.NoError(test, err)
+	require.Nil(test, lastEntry)
+}
+
+func TestVerify_tampered(test *testing.T) {
+	test.Parallel()
+	content, err := os.ReadFile(writeLog(test))
+	require.NoError(test, err)
+
+	lines := strings.SplitAfter(strings.TrimSpace(string(content)), "\n")
+	require.Len(test, lines, 3)
+
+	var entry audit.Entry
+
+	require.NoError(test, json.Unmarshal([]byte(lines[1]), &entry))
+	entry.Principal = "auditor"
+	changedLine, err := json.Marshal(entry)
+	require.NoError(test, err)
+
+	for name, tamperedLines := range map[string][]string{
+		"changed":   {lines[0], string(changedLine) + "\n", lines[2]},
+		"removed":   {lines[0], lines[2]},
+		"reordered": {lines[1], lines[0], lines[2]},
+		"replaced":  {strings.Replace(lines[0], "100001", "100009", 1), lines[1], lines[2]},
+	} {
+		_, err := audit.Verify(strings.NewReader(strings.Join(tamperedLines, "")), auditKey)
+		require.Error(test, err, name)
+	}
+}
+
+func TestVerify_otherKey(test *testing.T) {
+	test.Parallel()
+	content, err := os.ReadFile(writeLog(test))
+	require.NoError(test, err)
+
+	// A chain recomputed with any other key, or none, does not verify.
+
+	_, err = audit.Verify(bytes.NewReader(content), bytes.Repeat([]byte("k"), 32))
+	require.Error(test, err)
+
+	_, err = audit.Verify(bytes.NewReader(content), nil)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func callerContext(test *testing.T, name string, conversationID string) context.Context {
+	test.Helper()
+
+	ctx := identity.NewContext(test.Context(), &identity.Identity{Method: identity.MethodAPIKey, Name: name})
+
+	if len(conversationID) > 0 {
+		ctx = audit.NewContext(ctx, conversationID)
+	}
+
+	return ctx
+}
+
+func newAuditedEntityEngine(test *testing.T, filename string, attributes string) *audit.AuditedEntityEngine {
+	test.Helper()
+
+	auditLog, err := audit.OpenLog(filename, auditKey)
+	require.NoError(test, err)
+	test.Cleanup(func() { _ = auditLog.Close() })
+
+	return &audit.AuditedEntityEngine{
+		Attributes: attributes,
+		EntityEngine: &enginetest.EntityEngine{ //nolint:exhaustruct
+			EntityErrors: map[int64]error{0: errNotFound},
+			EntityJSON:   entityJSON,
+			HowJSON:      `{"HOW_RESULTS": {}}`,
+			SearchJSON:   searchJSON,
+		},
+		Log: auditLog,
+	}
+}
+
+func readEntries(test *testing.T, filename string) []audit.Entry {
+	test.Helper()
+
+	content, err := os.ReadFile(filename)
+	require.NoError(test, err)
+
+	var result []audit.Entry
+
+	for line := range strings.Lines(string(content)) {
+		var entry audit.Entry
+
+		require.NoError(test, json.Unmarshal([]byte(line), &entry))
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// A Log of three entries.
+func writeLog(test *testing.T) string {
+	test.Helper()
+
+	filename := filepath.Join(test.TempDir(), "audit.jsonl")
+	engine := newAuditedEntityEngine(test, filename, audit.AttributesHash)
+	ctx := callerContext(test, "analyst", "conversation-1")
+
+	_, err := engine.GetEntityByEntityID(ctx, 100001)
+	require.NoError(test, err)
+	_, err = engine.SearchByAttributes(ctx, searchAttributes)
+	require.NoError(test, err)
+	_, err = engine.HowEntityByEntityID(ctx, 100001)
+	require.NoError(test, err)
+	require.NoError(test, engine.Log.Close())
+
+	return filename
+}
@@ -0,0 +1,15 @@
+/*
+Package audit keeps a tamper-evident record of who looked up whom.
+
+AuditedEntityEngine appends an Entry to a Log for every Senzing call, whether it comes from
+the chat API, the console or a chat tool call.  An Entry names the caller, the operation,
+the search attributes, the entity IDs returned and the conversation the call was part of.
+
+The Log is a JSON Lines (JSONL) file that is only appended to.  Each Entry holds the hash of
+the Entry before it, so changing, removing or reordering entries breaks the chain, and Verify
+reports where.  The hashes are HMAC-SHA256, keyed with a secret kept outside the Log, so whoever
+can write the Log but not read the key cannot make a changed chain verify.  Whoever holds the key
+can.  Removing entries from the end leaves a shorter, valid chain; to detect that, keep the
+sequence number and hash that Verify reports somewhere the log's writers cannot change.
+*/
+package audit
@@ -0,0 +1,276 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Log is an append-only JSONL file of hash-chained entries.  Use OpenLog to create one.
+type Log struct {
+	file         *os.File
+	key          []byte
+	mutex        sync.Mutex
+	previousHash string
+	sequence     int64
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Append method chains an Entry to the previous one and writes it to the end of the Log.
+The file is synced before Append returns, so an audited result is never returned unrecorded.
+
+Input
+  - entry: The call to record.  Its Sequence, PreviousHash, Time and Hash are set by Append.
+
+Output
+  - An error if the Entry could not be written.
+*/
+func (auditLog *Log) Append(entry *Entry) error {
+	auditLog.mutex.Lock()
+	defer auditLog.mutex.Unlock()
+
+	entry.PreviousHash = auditLog.previousHash
+	entry.Sequence = auditLog.sequence + 1
+	entry.Time = time.Now().UTC()
+
+	hash, err := hashEntry(*entry, auditLog.key)
+	if err != nil {
+		return err
+	}
+
+	entry.Hash = hash
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return wraperror.Errorf(err, "json.Marshal")
+	}
+
+	_, err = auditLog.file.Write(append(line, '\n'))
+	if err != nil {
+		return wraperror.Errorf(err, "Write: %s", auditLog.file.Name())
+	}
+
+	err = auditLog.file.Sync()
+	if err != nil {
+		return wraperror.Errorf(err, "Sync: %s", auditLog.file.Name())
+	}
+
+	auditLog.previousHash = entry.Hash
+	auditLog.sequence = entry.Sequence
+
+	return nil
+}
+
+/*
+The Close method closes the Log's file.
+
+Output
+  - An error if the file could not be closed.
+*/
+func (auditLog *Log) Close() error {
+	auditLog.mutex.Lock()
+	defer auditLog.mutex.Unlock()
+
+	err := auditLog.file.Close()
+	if err != nil {
+		return wraperror.Errorf(err, "Close: %s", auditLog.file.Name())
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LoadKey function reads the secret that keys the hash chain of a Log.
+Surrounding whitespace, like a trailing newline, is not part of the key.
+
+Input
+  - filename: Path to a file of at least 32 bytes, e.g. written by "openssl rand -hex 32".
+
+Output
+  - The key, or an error if the file cannot be read or the key is too short.
+*/
+func LoadKey(filename string) ([]byte, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "os.ReadFile: %s", filename)
+	}
+
+	result := bytes.TrimSpace(content)
+
+	err = checkKey(result)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "checkKey: %s", filename)
+	}
+
+	return result, nil
+}
+
+/*
+The OpenLog function opens a Log for appending, creating the file if needed.
+An existing file is verified first and the chain continues from its last Entry.
+
+Input
+  - filename: Path to the JSONL file.
+  - key: The secret that keys the hash chain, see LoadKey.
+
+Output
+  - The Log, or an error if the file cannot be opened or fails verification.
+*/
+func OpenLog(filename string, key []byte) (*Log, error) {
+	err := checkKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_RDWR, logFilePerm)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "os.OpenFile: %s", filename)
+	}
+
+	lastEntry, err := Verify(file, key)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, wraperror.Errorf(err, "Verify: %s", filename)
+	}
+
+	result := &Log{
+		file:         file,
+		key:          key,
+		mutex:        sync.Mutex{},
+		previousHash: "",
+		sequence:     0,
+	}
+
+	if lastEntry != nil {
+		result.previousHash = lastEntry.Hash
+		result.sequence = lastEntry.Sequence
+	}
+
+	return result, nil
+}
+
+/*
+The Verify function checks that a Log has not been tampered with: every line must be an Entry
+whose hash matches its content, numbered one after the other and chained to the line before.
+
+Input
+  - reader: The content of a Log.
+  - key: The secret the Log was written with.
+
+Output
+  - The last Entry, or nil if the Log is empty.  Keep its Sequence and Hash to detect later truncation.
+  - An error naming the first line that fails verification.
+*/
+func Verify(reader io.Reader, key []byte) (*Entry, error) {
+	var result *Entry
+
+	err := checkKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for lineNumber := int64(1); scanner.Scan(); lineNumber++ {
+		entry := new(Entry)
+
+		err = json.Unmarshal(scanner.Bytes(), entry)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "json.Unmarshal line %d", lineNumber)
+		}
+
+		err = verifyEntry(entry, result, lineNumber, key)
+		if err != nil {
+			return nil, err
+		}
+
+		result = entry
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, wraperror.Errorf(err, "scanner.Err")
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func checkKey(key []byte) error {
+	if len(key) < minKeySize {
+		return wraperror.Errorf(errForPackage, "the audit key has %d bytes; it needs at least %d", len(key), minKeySize)
+	}
+
+	return nil
+}
+
+// The hex HMAC-SHA256 of an Entry's JSON with an empty Hash.  PreviousHash is part of it, which forms the chain.
+// Without the key, a changed Entry cannot be given a matching Hash.
+func hashEntry(entry Entry, key []byte) (string, error) {
+	entry.Hash = ""
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return "", wraperror.Errorf(err, "json.Marshal")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(content)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func verifyEntry(entry *Entry, previousEntry *Entry, lineNumber int64, key []byte) error {
+	var previousHash string
+
+	if previousEntry != nil {
+		previousHash = previousEntry.Hash
+	}
+
+	if entry.Sequence != lineNumber {
+		return wraperror.Errorf(errForPackage, "line %d: sequence is %d, expected %d",
+			lineNumber, entry.Sequence, lineNumber)
+	}
+
+	if entry.PreviousHash != previousHash {
+		return wraperror.Errorf(errForPackage, "line %d: previous_hash does not match the hash of line %d",
+			lineNumber, lineNumber-1)
+	}
+
+	hash, err := hashEntry(*entry, key)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(entry.Hash), []byte(hash)) {
+		return wraperror.Errorf(errForPackage, "line %d: hash does not match the entry", lineNumber)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Entry is one audited call.  Each Entry is one line of a Log.
+type Entry struct {
+	Attributes     json.RawMessage `json:"attributes,omitempty"`      // SearchByAttributes input, see Attributes*
+	ConversationID string          `json:"conversation_id,omitempty"` // Sent by the client in HeaderConversationID
+	EntityID       int64           `json:"entity_id,omitempty"`       // The entity asked for
+	EntityIDs      []int64         `json:"entity_ids"`                // The entities returned to the caller
+	Error          string          `json:"error,omitempty"`
+	Hash           string          `json:"hash"` // HMAC-SHA256 of the Entry, with an empty Hash
+	Operation      string          `json:"operation"`
+	PreviousHash   string          `json:"previous_hash"` // Hash of the Entry before; empty for the first
+	Principal      string          `json:"principal"`     // Caller as named in policies, e.g. "api-key:analyst"
+	Sequence       int64           `json:"sequence"`      // 1 for the first Entry of a Log
+	Time           time.Time       `json:"time"`
+}
+
+type contextKey struct{}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// How search attributes are written to the Log.
+const (
+	// Each value is replaced by "hmac-sha256:" and its hex HMAC-SHA256, under a key derived from the Log's
+	// key, so an auditor holding the key can check whether a known value was searched for.  Without the key,
+	// hashes of guessable values, like dates of birth, cannot be reversed by trying every value.
+	AttributesHash = "hash"
+
+	// Each value is replaced by RedactedValue; only the attribute names are kept.
+	AttributesRedact = "redact"
+
+	// The attributes are kept as given, so the Log holds PII.
+	AttributesVerbatim = "verbatim"
+)
+
+// HeaderConversationID is the HTTP request header in which a chat client identifies its conversation.
+const HeaderConversationID = "X-Conversation-ID"
+
+// RedactedValue replaces search attribute values with AttributesRedact.
+const RedactedValue = "[REDACTED]"
+
+// Values of Entry.Operation.
+const (
+	OperationGetEntityByEntityID = "GetEntityByEntityID"
+	OperationHowEntityByEntityID = "HowEntityByEntityID"
+	OperationSearchByAttributes  = "SearchByAttributes"
+)
+
+// Longest conversation ID, in bytes, kept by NewContext.
+const maxConversationIDLength = 256
+
+// Label from which the key of attribute hashes is derived.
+const attributeKeyLabel = "serve-chat audit attributes"
+
+// Shortest key, in bytes, accepted by LoadKey, OpenLog and Verify.
+const minKeySize = 32
+
+// Largest line, in bytes, accepted by Verify.
+const maxLineSize = 64 * 1024 * 1024
+
+const logFilePerm = 0o600
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("audit")
+
+var attributeModes = []string{AttributesHash, AttributesRedact, AttributesVerbatim}
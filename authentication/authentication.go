@@ -0,0 +1,292 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/identity"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// APIKeyAuthenticator accepts the API keys listed in APIKeyFile.
+type APIKeyAuthenticator struct {
+	APIKeyFile string // JSON object mapping the hex SHA-256 hash of each API key to the name of its holder
+	names      map[string]string
+}
+
+// JWTAuthenticator accepts JWT bearer tokens signed by a key in JWKSFile, or published by Issuer.
+type JWTAuthenticator struct {
+	Audience  string // When set, tokens must name it in their "aud" claim
+	Issuer    string // When set, tokens must name it in their "iss" claim; without JWKSFile, its keys are discovered
+	JWKSFile  string // JSON Web Key Set of the keys that sign tokens
+	NameClaim string // Claim holding the name of the caller; default "sub"
+	fetchedAt time.Time
+	keys      map[string]any
+	mutex     sync.RWMutex
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Authenticate method identifies the caller by the API key in the X-API-Key header.
+
+Input
+  - request: The HTTP request.
+
+Output
+  - The Identity of the key's holder.
+*/
+func (authenticator *APIKeyAuthenticator) Authenticate(request *http.Request) (*identity.Identity, error) {
+	apiKey := request.Header.Get(HeaderAPIKey)
+	if len(apiKey) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	name, ok := authenticator.names[HashAPIKey(apiKey)]
+	if !ok {
+		return nil, wraperror.Errorf(errForPackage, "unknown API key")
+	}
+
+	return &identity.Identity{
+		Method: identity.MethodAPIKey,
+		Name:   name,
+	}, nil
+}
+
+/*
+The Authenticate method identifies the caller by the JWT in the "Authorization: Bearer" header.
+The token must be signed with an asymmetric algorithm by a known key, must not be expired,
+and must match Issuer and Audience when they are set.
+
+Input
+  - request: The HTTP request.
+
+Output
+  - The Identity named by the token's NameClaim.
+*/
+func (authenticator *JWTAuthenticator) Authenticate(request *http.Request) (*identity.Identity, error) {
+	scheme, tokenString, found := strings.Cut(request.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, ErrNoCredentials
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithExpirationRequired(),
+	}
+
+	if len(authenticator.Audience) > 0 {
+		parserOptions = append(parserOptions, jwt.WithAudience(authenticator.Audience))
+	}
+
+	if len(authenticator.Issuer) > 0 {
+		parserOptions = append(parserOptions, jwt.WithIssuer(authenticator.Issuer))
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		strings.TrimSpace(tokenString),
+		claims,
+		func(token *jwt.Token) (any, error) { return authenticator.getKey(request.Context(), token) },
+		parserOptions...,
+	)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "jwt.Parse")
+	}
+
+	name, ok := claims[authenticator.nameClaim()].(string)
+	if !ok || len(name) == 0 {
+		return nil, wraperror.Errorf(errForPackage, "token has no %q claim", authenticator.nameClaim())
+	}
+
+	return &identity.Identity{
+		Method: identity.MethodBearerToken,
+		Name:   name,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Load method reads APIKeyFile.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - An error if the file cannot be read or is not a JSON object of hashes to names.
+*/
+func (authenticator *APIKeyAuthenticator) Load(ctx context.Context) error {
+	_ = ctx
+
+	content, err := os.ReadFile(authenticator.APIKeyFile)
+	if err != nil {
+		return wraperror.Errorf(err, "os.ReadFile: %s", authenticator.APIKeyFile)
+	}
+
+	var names map[string]string
+
+	err = json.Unmarshal(content, &names)
+	if err != nil {
+		return wraperror.Errorf(err, "json.Unmarshal: %s", authenticator.APIKeyFile)
+	}
+
+	authenticator.names = make(map[string]string, len(names))
+
+	for hash, name := range names {
+		hash = strings.ToLower(strings.TrimPrefix(hash, "sha256:"))
+
+		decoded, err := hex.DecodeString(hash)
+		if err != nil || len(decoded) != sha256.Size {
+			return wraperror.Errorf(errForPackage, "%s: %q is not a hex SHA-256 hash", authenticator.APIKeyFile, hash)
+		}
+
+		authenticator.names[hash] = name
+	}
+
+	return nil
+}
+
+/*
+The Load method reads the signing keys from JWKSFile or, if it is not set, from the
+JWKS document published by Issuer.  Keys published by Issuer are fetched again when a
+token names a key that is not yet known, so key rotation needs no restart.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - An error if the keys cannot be read.
+*/
+func (authenticator *JWTAuthenticator) Load(ctx context.Context) error {
+	authenticator.mutex.Lock()
+	defer authenticator.mutex.Unlock()
+
+	return authenticator.loadKeys(ctx)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The HashAPIKey function returns the hash by which an API key is listed in an APIKeyFile.
+
+Input
+  - apiKey: The API key.
+
+Output
+  - The hex encoded SHA-256 hash of the API key.
+*/
+func HashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Find the key that signed the token, by its "kid" header.  A token without "kid" may use the only key.
+func (authenticator *JWTAuthenticator) getKey(ctx context.Context, token *jwt.Token) (any, error) {
+	keyID, _ := token.Header["kid"].(string)
+
+	authenticator.mutex.RLock()
+	key, ok := authenticator.lookupKey(keyID)
+	authenticator.mutex.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if len(authenticator.JWKSFile) == 0 && len(authenticator.Issuer) > 0 {
+		authenticator.mutex.Lock()
+		defer authenticator.mutex.Unlock()
+
+		if time.Since(authenticator.fetchedAt) >= jwksMinimumRefresh {
+			err := authenticator.loadKeys(ctx)
+			if err != nil {
+				return nil, wraperror.Errorf(err, "loadKeys")
+			}
+		}
+
+		key, ok = authenticator.lookupKey(keyID)
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, wraperror.Errorf(errForPackage, "unknown signing key %q", keyID)
+}
+
+func (authenticator *JWTAuthenticator) lookupKey(keyID string) (any, bool) {
+	if len(keyID) == 0 && len(authenticator.keys) == 1 {
+		for _, key := range authenticator.keys {
+			return key, true
+		}
+	}
+
+	key, ok := authenticator.keys[keyID]
+
+	return key, ok
+}
+
+// The caller holds the write lock.
+func (authenticator *JWTAuthenticator) loadKeys(ctx context.Context) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch {
+	case len(authenticator.JWKSFile) > 0:
+		content, err = os.ReadFile(authenticator.JWKSFile)
+		if err != nil {
+			return wraperror.Errorf(err, "os.ReadFile: %s", authenticator.JWKSFile)
+		}
+	case len(authenticator.Issuer) > 0:
+		authenticator.fetchedAt = time.Now()
+
+		content, err = fetchIssuerJWKS(ctx, authenticator.Issuer)
+		if err != nil {
+			return wraperror.Errorf(err, "fetchIssuerJWKS: %s", authenticator.Issuer)
+		}
+	default:
+		return wraperror.Errorf(errForPackage, "neither JWKSFile nor Issuer is set")
+	}
+
+	keys, err := parseJWKS(content)
+	if err != nil {
+		return wraperror.Errorf(err, "parseJWKS")
+	}
+
+	authenticator.keys = keys
+
+	return nil
+}
+
+func (authenticator *JWTAuthenticator) nameClaim() string {
+	if len(authenticator.NameClaim) == 0 {
+		return defaultNameClaim
+	}
+
+	return authenticator.NameClaim
+}
@@ -0,0 +1,207 @@
+package authentication_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/senzing-garage/serve-chat/authentication"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAPIKeyAuthenticator_Authenticate(test *testing.T) {
+	test.Parallel()
+	authenticator := &authentication.APIKeyAuthenticator{
+		APIKeyFile: writeJSON(test, map[string]string{
+			authentication.HashAPIKey("analyst-key"): "analyst",
+		}),
+	}
+	require.NoError(test, authenticator.Load(test.Context()))
+
+	result, err := authenticator.Authenticate(newRequest(test, authentication.HeaderAPIKey, "analyst-key"))
+	require.NoError(test, err)
+	require.Equal(test, &identity.Identity{Method: identity.MethodAPIKey, Name: "analyst"}, result)
+
+	_, err = authenticator.Authenticate(newRequest(test, authentication.HeaderAPIKey, "guessed-key"))
+	require.Error(test, err)
+	require.NotErrorIs(test, err, authentication.ErrNoCredentials)
+
+	_, err = authenticator.Authenticate(newRequest(test, "", ""))
+	require.ErrorIs(test, err, authentication.ErrNoCredentials)
+}
+
+func TestAPIKeyAuthenticator_Load_badHash(test *testing.T) {
+	test.Parallel()
+	authenticator := &authentication.APIKeyAuthenticator{
+		APIKeyFile: writeJSON(test, map[string]string{"analyst-key": "analyst"}),
+	}
+	require.Error(test, authenticator.Load(test.Context()))
+}
+
+func TestJWTAuthenticator_Authenticate(test *testing.T) {
+	test.Parallel()
+	key := newSigningKey(test)
+	authenticator := &authentication.JWTAuthenticator{
+		Audience: "serve-chat",
+		JWKSFile: writeJSON(test, newJWKS(key, "key-1")),
+	}
+	require.NoError(test, authenticator.Load(test.Context()))
+
+	token := newToken(test, key, "key-1", jwt.MapClaims{
+		"aud": "serve-chat",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"sub": "analyst",
+	})
+	result, err := authenticator.Authenticate(newRequest(test, "Authorization", "Bearer "+token))
+	require.NoError(test, err)
+	require.Equal(test, &identity.Identity{Method: identity.MethodBearerToken, Name: "analyst"}, result)
+
+	_, err = authenticator.Authenticate(newRequest(test, "", ""))
+	require.ErrorIs(test, err, authentication.ErrNoCredentials)
+}
+
+func TestJWTAuthenticator_Authenticate_rejected(test *testing.T) {
+	test.Parallel()
+	key := newSigningKey(test)
+	authenticator := &authentication.JWTAuthenticator{
+		Audience: "serve-chat",
+		JWKSFile: writeJSON(test, newJWKS(key, "key-1")),
+	}
+	require.NoError(test, authenticator.Load(test.Context()))
+
+	testCases := map[string]string{
+		"expired": newToken(test, key, "key-1", jwt.MapClaims{
+			"aud": "serve-chat",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+			"sub": "analyst",
+		}),
+		"no expiry": newToken(test, key, "key-1", jwt.MapClaims{"aud": "serve-chat", "sub": "analyst"}),
+		"other audience": newToken(test, key, "key-1", jwt.MapClaims{
+			"aud": "other",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"sub": "analyst",
+		}),
+		"unknown key": newToken(test, newSigningKey(test), "key-2", jwt.MapClaims{
+			"aud": "serve-chat",
+			"exp": time.Now().Add(time.Hour).Unix(),
+			"sub": "analyst",
+		}),
+		"unsigned": "eyJhbGciOiJub25lIn0.eyJzdWIiOiJhbmFseXN0In0.",
+	}
+
+	for name, token := range testCases {
+		_, err := authenticator.Authenticate(newRequest(test, "Authorization", "Bearer "+token))
+		require.Error(test, err, name)
+		require.NotErrorIs(test, err, authentication.ErrNoCredentials, name)
+	}
+}
+
+func TestJWTAuthenticator_Load_issuer(test *testing.T) {
+	test.Parallel()
+	key := newSigningKey(test)
+	mux := http.NewServeMux()
+	issuer := httptest.NewServer(mux)
+	test.Cleanup(issuer.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(writer http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(writer).Encode(map[string]string{
+			"issuer":   issuer.URL,
+			"jwks_uri": issuer.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(writer http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(writer).Encode(newJWKS(key, "key-1"))
+	})
+
+	authenticator := &authentication.JWTAuthenticator{
+		Issuer:    issuer.URL,
+		NameClaim: "email",
+	}
+	require.NoError(test, authenticator.Load(test.Context()))
+
+	token := newToken(test, key, "key-1", jwt.MapClaims{
+		"email": "analyst@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iss":   issuer.URL,
+	})
+	result, err := authenticator.Authenticate(newRequest(test, "Authorization", "Bearer "+token))
+	require.NoError(test, err)
+	require.Equal(test, "analyst@example.com", result.Name)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func newJWKS(key *ecdsa.PrivateKey, keyID string) map[string]any {
+	return map[string]any{
+		"keys": []map[string]string{{
+			"crv": "P-256",
+			"kid": keyID,
+			"kty": "EC",
+			"use": "sig",
+			"x":   base64.RawURLEncoding.EncodeToString(key.PublicKey.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(key.PublicKey.Y.FillBytes(make([]byte, 32))),
+		}},
+	}
+}
+
+func newRequest(test *testing.T, header string, value string) *http.Request {
+	test.Helper()
+
+	request, err := http.NewRequestWithContext(test.Context(), http.MethodGet, "/chat/entity_details", nil)
+	require.NoError(test, err)
+
+	if len(header) > 0 {
+		request.Header.Set(header, value)
+	}
+
+	return request
+}
+
+func newSigningKey(test *testing.T) *ecdsa.PrivateKey {
+	test.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(test, err)
+
+	return key
+}
+
+func newToken(test *testing.T, key *ecdsa.PrivateKey, keyID string, claims jwt.MapClaims) string {
+	test.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = keyID
+
+	result, err := token.SignedString(key)
+	require.NoError(test, err)
+
+	return result
+}
+
+func writeJSON(test *testing.T, value any) string {
+	test.Helper()
+
+	content, err := json.Marshal(value)
+	require.NoError(test, err)
+
+	result := filepath.Join(test.TempDir(), "test.json")
+	require.NoError(test, os.WriteFile(result, content, 0o600))
+
+	return result
+}
@@ -0,0 +1,9 @@
+/*
+Package authentication determines the identity of the caller of an HTTP request.
+
+An APIKeyAuthenticator accepts static API keys, sent in the X-API-Key header and
+listed by their SHA-256 hash in a file.
+A JWTAuthenticator accepts JWT bearer tokens, sent in the Authorization header and
+validated against a local JWKS file or the keys published by an OpenID Connect issuer.
+*/
+package authentication
@@ -0,0 +1,230 @@
+package authentication
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A JSON Web Key, RFC 7517.  Only the members of public signing keys are read.
+type jsonWebKey struct {
+	Crv string `json:"crv"`
+	E   string `json:"e"`
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	Use string `json:"use"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Fetch the JWKS of an OpenID Connect issuer, via the jwks_uri of its discovery document.
+func fetchIssuerJWKS(ctx context.Context, issuer string) ([]byte, error) {
+	discoveryJSON, err := httpGet(ctx, strings.TrimSuffix(issuer, "/")+discoveryPath)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "httpGet discovery document")
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"` //nolint:tagliatelle
+	}
+
+	err = json.Unmarshal(discoveryJSON, &discovery)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal discovery document")
+	}
+
+	if discovery.Issuer != issuer {
+		return nil, wraperror.Errorf(errForPackage, "discovery document is for issuer %q", discovery.Issuer)
+	}
+
+	if len(discovery.JWKSURI) == 0 {
+		return nil, wraperror.Errorf(errForPackage, "discovery document has no jwks_uri")
+	}
+
+	result, err := httpGet(ctx, discovery.JWKSURI)
+
+	return result, wraperror.Errorf(err, "httpGet %s", discovery.JWKSURI)
+}
+
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksRequestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "http.NewRequestWithContext")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Do")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, wraperror.Errorf(errForPackage, "%s returned %s", url, response.Status)
+	}
+
+	result, err := io.ReadAll(io.LimitReader(response.Body, jwksResponseSizeLimit))
+
+	return result, wraperror.Errorf(err, "io.ReadAll")
+}
+
+// Parse the signing keys of a JWKS, by key ID.  Encryption keys and unsupported key types are skipped.
+func parseJWKS(content []byte) (map[string]any, error) {
+	var keySet jsonWebKeySet
+
+	err := json.Unmarshal(content, &keySet)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	result := map[string]any{}
+
+	for _, key := range keySet.Keys {
+		if len(key.Use) > 0 && key.Use != "sig" {
+			continue
+		}
+
+		var publicKey any
+
+		switch key.Kty {
+		case "EC":
+			publicKey, err = parseECKey(key)
+		case "OKP":
+			publicKey, err = parseOKPKey(key)
+		case "RSA":
+			publicKey, err = parseRSAKey(key)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, wraperror.Errorf(err, "key %q", key.Kid)
+		}
+
+		result[key.Kid] = publicKey
+	}
+
+	if len(result) == 0 {
+		return nil, wraperror.Errorf(errForPackage, "no signing keys in JWKS")
+	}
+
+	return result, nil
+}
+
+func parseECKey(key jsonWebKey) (*ecdsa.PublicKey, error) {
+	var (
+		curve     elliptic.Curve
+		ecdhCurve ecdh.Curve
+	)
+
+	switch key.Crv {
+	case "P-256":
+		curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+	case "P-384":
+		curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+	case "P-521":
+		curve, ecdhCurve = elliptic.P521(), ecdh.P521()
+	default:
+		return nil, wraperror.Errorf(errForPackage, "unsupported curve %q", key.Crv)
+	}
+
+	size := (curve.Params().BitSize + 7) / 8 //nolint:mnd
+
+	x, err := decodeFixed(key.X, size)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "x")
+	}
+
+	y, err := decodeFixed(key.Y, size)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "y")
+	}
+
+	// Reject points that are not on the curve.
+
+	_, err = ecdhCurve.NewPublicKey(append(append([]byte{4}, x...), y...))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "NewPublicKey")
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
+
+func parseOKPKey(key jsonWebKey) (ed25519.PublicKey, error) {
+	if key.Crv != "Ed25519" {
+		return nil, wraperror.Errorf(errForPackage, "unsupported curve %q", key.Crv)
+	}
+
+	x, err := decodeFixed(key.X, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "x")
+	}
+
+	return ed25519.PublicKey(x), nil
+}
+
+func parseRSAKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "n")
+	}
+
+	exponent, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "e")
+	}
+
+	publicExponent := new(big.Int).SetBytes(exponent)
+	if !publicExponent.IsInt64() || publicExponent.Int64() < 3 || publicExponent.Int64() > 1<<31-1 {
+		return nil, wraperror.Errorf(errForPackage, "unsupported exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(publicExponent.Int64()),
+	}, nil
+}
+
+func decodeFixed(value string, size int) ([]byte, error) {
+	result, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "base64 decode")
+	}
+
+	if len(result) != size {
+		return nil, wraperror.Errorf(errForPackage, "%d bytes, not %d", len(result), size)
+	}
+
+	return result, nil
+}
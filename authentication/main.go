@@ -0,0 +1,58 @@
+package authentication
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/senzing-garage/serve-chat/identity"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An Authenticator determines the caller of a request from the credentials it carries.
+// If the request carries no credentials of the Authenticator's kind, Authenticate returns ErrNoCredentials.
+// Any other error means the credentials were presented and rejected.
+type Authenticator interface {
+	Authenticate(request *http.Request) (*identity.Identity, error)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// HTTP header carrying an API key.
+const HeaderAPIKey = "X-API-Key"
+
+// Names of the security schemes in the OpenAPI specification.
+const (
+	SecuritySchemeAPIKey = "apiKey"
+	SecuritySchemeBearer = "bearerAuth"
+)
+
+const (
+	defaultNameClaim      = "sub"
+	discoveryPath         = "/.well-known/openid-configuration"
+	jwksMinimumRefresh    = time.Minute
+	jwksRequestTimeout    = 10 * time.Second
+	jwksResponseSizeLimit = 1 << 20
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// ErrNoCredentials is returned by an Authenticator when a request carries none of its credentials.
+var ErrNoCredentials = errors.New("no credentials")
+
+var errForPackage = errors.New("authentication")
+
+// Signing algorithms accepted in JWTs.  "none" and HMAC algorithms are never accepted.
+var validMethods = []string{
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+	"PS256", "PS384", "PS512",
+	"RS256", "RS384", "RS512",
+}
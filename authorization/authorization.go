@@ -0,0 +1,238 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// AuthorizedEntityEngine passes calls to EntityEngine when Policy permits them to the caller,
+// and removes records of data sources the caller may not see from the results.
+type AuthorizedEntityEngine struct {
+	EntityEngine senzingchatservice.EntityEngine
+	Policy       *Policy
+}
+
+// Permissions are the operations and data sources permitted to a caller by all of its roles.
+type Permissions struct {
+	caller      string
+	dataSources map[string]bool
+	operations  map[string]bool
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID requires OperationReport and at least one record the caller may see.
+func (engine *AuthorizedEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	permissions := engine.Policy.Permissions(ctx)
+
+	err := permissions.check(OperationReport)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := engine.EntityEngine.GetEntityByEntityID(ctx, entityID)
+	if err != nil {
+		return result, err //nolint:wrapcheck
+	}
+
+	return permissions.filterEntityDocument(result)
+}
+
+// HowEntityByEntityID requires OperationHow and that the caller may see every record of the entity,
+// because each resolution step shows the records it joined.
+func (engine *AuthorizedEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	permissions := engine.Policy.Permissions(ctx)
+
+	err := permissions.check(OperationHow)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := engine.EntityEngine.HowEntityByEntityID(ctx, entityID)
+	if err != nil {
+		return result, err //nolint:wrapcheck
+	}
+
+	return permissions.checkHowDocument(result)
+}
+
+// SearchByAttributes requires OperationSearch; entities with no record the caller may see are removed.
+func (engine *AuthorizedEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	permissions := engine.Policy.Permissions(ctx)
+
+	err := permissions.check(OperationSearch)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := engine.EntityEngine.SearchByAttributes(ctx, attributes)
+	if err != nil {
+		return result, err //nolint:wrapcheck
+	}
+
+	return permissions.filterSearchDocument(result)
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Permissions method determines what the caller of a request may do.
+A caller with no Identity in its context is permitted nothing.
+
+Input
+  - ctx: The context of the request, carrying the caller's Identity.
+
+Output
+  - The union of the permissions of the caller's roles.
+*/
+func (policy *Policy) Permissions(ctx context.Context) *Permissions {
+	result := &Permissions{
+		caller:      "anonymous caller",
+		dataSources: map[string]bool{},
+		operations:  map[string]bool{},
+	}
+
+	caller, ok := identity.FromContext(ctx)
+	if !ok {
+		return result
+	}
+
+	result.caller = Principal(caller)
+
+	roleNames := slices.Concat(policy.Principals[result.caller], policy.Principals[Wildcard])
+	for _, roleName := range roleNames {
+		role := policy.Roles[roleName]
+
+		for _, dataSource := range role.DataSources {
+			result.dataSources[dataSource] = true
+		}
+
+		for _, operation := range role.Operations {
+			result.operations[operation] = true
+		}
+	}
+
+	return result
+}
+
+/*
+The PermitsDataSource method reports whether the caller may see records of a data source.
+
+Input
+  - dataSource: A DATA_SOURCE code.
+
+Output
+  - True if one of the caller's roles permits the data source.
+*/
+func (permissions *Permissions) PermitsDataSource(dataSource string) bool {
+	return permissions.dataSources[Wildcard] || permissions.dataSources[dataSource]
+}
+
+/*
+The PermitsOperation method reports whether the caller may perform an operation.
+
+Input
+  - operation: One of the Operation* constants.
+
+Output
+  - True if one of the caller's roles permits the operation.
+*/
+func (permissions *Permissions) PermitsOperation(operation string) bool {
+	return permissions.operations[Wildcard] || permissions.operations[operation]
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The LoadPolicy function reads and checks an authorization policy file.
+
+Input
+  - filename: Path to a JSON Policy.
+
+Output
+  - The Policy, or an error if a principal is not "*" or "<method>:<name>", a principal is granted an undefined
+    role, or a role names an unknown operation.
+*/
+func LoadPolicy(filename string) (*Policy, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "os.ReadFile: %s", filename)
+	}
+
+	result := new(Policy)
+
+	err = json.Unmarshal(content, result)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal: %s", filename)
+	}
+
+	for principal, roleNames := range result.Principals {
+		method, name, ok := strings.Cut(principal, ":")
+		if principal != Wildcard && (!ok || len(method) == 0 || len(name) == 0) {
+			return nil, wraperror.Errorf(errForPackage, "%s: principal %q is not %q or \"<method>:<name>\", e.g. %q",
+				filename, principal, Wildcard, identity.MethodAPIKey+":analyst")
+		}
+
+		for _, roleName := range roleNames {
+			if _, ok := result.Roles[roleName]; !ok {
+				return nil, wraperror.Errorf(errForPackage, "%s: principal %q has undefined role %q",
+					filename, principal, roleName)
+			}
+		}
+	}
+
+	for roleName, role := range result.Roles {
+		for _, operation := range role.Operations {
+			if !slices.Contains(operations, operation) {
+				return nil, wraperror.Errorf(errForPackage, "%s: role %q has unknown operation %q",
+					filename, roleName, operation)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+/*
+The Principal function names an authenticated caller the way Policy.Principals does.
+The authentication method is part of the name, so an API key named "analyst" is not granted the roles of
+a client certificate whose common name is "analyst".
+
+Input
+  - caller: The authenticated caller.
+
+Output
+  - "<method>:<name>", e.g. "client-certificate:analyst".
+*/
+func Principal(caller *identity.Identity) string {
+	return caller.Method + ":" + caller.Name
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (permissions *Permissions) check(operation string) error {
+	if permissions.PermitsOperation(operation) {
+		return nil
+	}
+
+	return wraperror.Errorf(senzingchatservice.ErrForbidden, "%s may not %s", permissions.caller, operation)
+}
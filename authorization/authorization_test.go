@@ -0,0 +1,239 @@
+package authorization_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/authorization"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/senzing-garage/serve-chat/internal/enginetest"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	entityJSON = `{
+		"RESOLVED_ENTITY": {
+			"ENTITY_ID": 100001,
+			"ENTITY_NAME": "Robert Smith",
+			"FEATURES": {"SSN": [{"FEAT_DESC": "123-45-6789"}]},
+			"RECORDS": [
+				{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"},
+				{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "7"}
+			]
+		},
+		"RELATED_ENTITIES": [
+			{"ENTITY_ID": 100002, "ENTITY_NAME": "Bob Smith", "RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS"}]},
+			{"ENTITY_ID": 100003, "ENTITY_NAME": "Rob Smith", "RECORD_SUMMARY": [{"DATA_SOURCE": "WATCHLIST"}]},
+			{
+				"ENTITY_ID": 100004,
+				"ENTITY_NAME": "R Smith",
+				"MATCH_INFO": {"MATCH_KEY": "+SSN", "FEATURE_SCORES": {"SSN": []}},
+				"MATCH_KEY": "+SSN",
+				"RECORD_SUMMARY": [{"DATA_SOURCE": "CUSTOMERS"}]
+			}
+		]
+	}`
+	howJSON = `{
+		"HOW_RESULTS": {
+			"FINAL_STATE": {
+				"VIRTUAL_ENTITIES": [{"MEMBER_RECORDS": [
+					{"RECORDS": [{"DATA_SOURCE": "CUSTOMERS", "RECORD_ID": "1001"}]},
+					{"RECORDS": [{"DATA_SOURCE": "WATCHLIST", "RECORD_ID": "7"}]}
+				]}]
+			}
+		}
+	}`
+	searchJSON = `{
+		"RESOLVED_ENTITIES": [
+			{
+				"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 100001, "RECORD_SUMMARY": [
+					{"DATA_SOURCE": "CUSTOMERS"}, {"DATA_SOURCE": "WATCHLIST"}
+				]}},
+				"MATCH_INFO": {"MATCH_KEY": "+NAME+SSN", "FEATURE_SCORES": {"SSN": []}}
+			},
+			{
+				"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 100003, "RECORD_SUMMARY": [{"DATA_SOURCE": "WATCHLIST"}]}},
+				"MATCH_INFO": {"MATCH_KEY": "+NAME"}
+			}
+		]
+	}`
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAuthorizedEntityEngine_GetEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	engine := newAuthorizedEntityEngine(test)
+
+	result, err := engine.GetEntityByEntityID(callerContext(test, "analyst"), 100001)
+	require.NoError(test, err)
+	require.Contains(test, result, `"RECORD_ID":"1001"`)
+	require.Contains(test, result, `"ENTITY_ID":100002`)
+	require.NotContains(test, result, "WATCHLIST")
+	require.NotContains(test, result, "123-45-6789")
+	require.NotContains(test, result, "Rob Smith")
+
+	result, err = engine.GetEntityByEntityID(callerContext(test, "investigator"), 100001)
+	require.NoError(test, err)
+	require.JSONEq(test, entityJSON, result)
+
+	_, err = engine.GetEntityByEntityID(callerContext(test, "auditor"), 100001)
+	require.ErrorIs(test, err, senzingchatservice.ErrForbidden)
+
+	_, err = engine.GetEntityByEntityID(test.Context(), 100001)
+	require.ErrorIs(test, err, senzingchatservice.ErrForbidden)
+}
+
+// Entity 100004 is related only through the SSN of the hidden WATCHLIST record.
+func TestAuthorizedEntityEngine_GetEntityByEntityID_hiddenRelationship(test *testing.T) {
+	test.Parallel()
+	engine := newAuthorizedEntityEngine(test)
+
+	result, err := engine.GetEntityByEntityID(callerContext(test, "analyst"), 100001)
+	require.NoError(test, err)
+	require.Contains(test, result, `"ENTITY_ID":100004`)
+	require.NotContains(test, result, "MATCH_INFO")
+	require.NotContains(test, result, "MATCH_KEY")
+	require.NotContains(test, result, "+SSN")
+
+	result, err = engine.GetEntityByEntityID(callerContext(test, "investigator"), 100001)
+	require.NoError(test, err)
+	require.Contains(test, result, `"MATCH_KEY": "+SSN"`)
+}
+
+func TestAuthorizedEntityEngine_HowEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	engine := newAuthorizedEntityEngine(test)
+
+	_, err := engine.HowEntityByEntityID(callerContext(test, "analyst"), 100001)
+	require.ErrorIs(test, err, senzingchatservice.ErrForbidden)
+
+	result, err := engine.HowEntityByEntityID(callerContext(test, "investigator"), 100001)
+	require.NoError(test, err)
+	require.Equal(test, howJSON, result)
+}
+
+func TestAuthorizedEntityEngine_SearchByAttributes(test *testing.T) {
+	test.Parallel()
+	engine := newAuthorizedEntityEngine(test)
+
+	result, err := engine.SearchByAttributes(callerContext(test, "analyst"), `{"NAME_FULL": "Robert Smith"}`)
+	require.NoError(test, err)
+	require.Contains(test, result, `"ENTITY_ID":100001`)
+	require.Contains(test, result, "+NAME+SSN")
+	require.NotContains(test, result, "WATCHLIST")
+	require.NotContains(test, result, "FEATURE_SCORES")
+	require.NotContains(test, result, `"ENTITY_ID":100003`)
+
+	_, err = engine.SearchByAttributes(callerContext(test, "auditor"), `{"NAME_FULL": "Robert Smith"}`)
+	require.ErrorIs(test, err, senzingchatservice.ErrForbidden)
+}
+
+func TestPolicy_Permissions_method(test *testing.T) {
+	test.Parallel()
+	engine := newAuthorizedEntityEngine(test)
+
+	// A client certificate named like an API key is not granted the API key's roles.
+
+	ctx := identity.NewContext(test.Context(), &identity.Identity{
+		Method: identity.MethodClientCertificate,
+		Name:   "investigator",
+	})
+	permissions := engine.Policy.Permissions(ctx)
+	require.False(test, permissions.PermitsOperation(authorization.OperationSearch))
+
+	permissions = engine.Policy.Permissions(callerContext(test, "investigator"))
+	require.True(test, permissions.PermitsOperation(authorization.OperationSearch))
+}
+
+func TestLoadPolicy_undefinedRole(test *testing.T) {
+	test.Parallel()
+	_, err := authorization.LoadPolicy(writePolicy(test, authorization.Policy{
+		Principals: map[string][]string{"api-key:analyst": {"analyst"}},
+		Roles:      map[string]authorization.Role{},
+	}))
+	require.Error(test, err)
+}
+
+func TestLoadPolicy_principalWithoutMethod(test *testing.T) {
+	test.Parallel()
+	_, err := authorization.LoadPolicy(writePolicy(test, authorization.Policy{
+		Principals: map[string][]string{"analyst": {"analyst"}},
+		Roles: map[string]authorization.Role{
+			"analyst": {DataSources: []string{"*"}, Operations: []string{authorization.OperationSearch}},
+		},
+	}))
+	require.Error(test, err)
+}
+
+func TestLoadPolicy_unknownOperation(test *testing.T) {
+	test.Parallel()
+	_, err := authorization.LoadPolicy(writePolicy(test, authorization.Policy{
+		Principals: map[string][]string{},
+		Roles: map[string]authorization.Role{
+			"analyst": {DataSources: []string{"*"}, Operations: []string{"delete"}},
+		},
+	}))
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func callerContext(test *testing.T, name string) context.Context {
+	test.Helper()
+
+	return identity.NewContext(test.Context(), &identity.Identity{Method: identity.MethodAPIKey, Name: name})
+}
+
+func newAuthorizedEntityEngine(test *testing.T) *authorization.AuthorizedEntityEngine {
+	test.Helper()
+
+	policy, err := authorization.LoadPolicy(writePolicy(test, authorization.Policy{
+		Principals: map[string][]string{
+			"api-key:analyst":      {"customer-analyst"},
+			"api-key:investigator": {"customer-analyst", "investigator"},
+			"*":                    {"auditor"},
+		},
+		Roles: map[string]authorization.Role{
+			"auditor": {DataSources: []string{}, Operations: []string{}},
+			"customer-analyst": {
+				DataSources: []string{"CUSTOMERS"},
+				Operations:  []string{authorization.OperationReport, authorization.OperationSearch},
+			},
+			"investigator": {
+				DataSources: []string{authorization.Wildcard},
+				Operations:  []string{authorization.Wildcard},
+			},
+		},
+	}))
+	require.NoError(test, err)
+
+	return &authorization.AuthorizedEntityEngine{
+		EntityEngine: &enginetest.EntityEngine{ //nolint:exhaustruct
+			EntityJSON: entityJSON,
+			HowJSON:    howJSON,
+			SearchJSON: searchJSON,
+		},
+		Policy: policy,
+	}
+}
+
+func writePolicy(test *testing.T, policy authorization.Policy) string {
+	test.Helper()
+
+	content, err := json.Marshal(policy)
+	require.NoError(test, err)
+
+	result := filepath.Join(test.TempDir(), "policy.json")
+	require.NoError(test, os.WriteFile(result, content, 0o600))
+
+	return result
+}
@@ -0,0 +1,11 @@
+/*
+Package authorization decides which operations and Senzing data sources a caller may use.
+
+A Policy, read from a JSON file, grants roles to principals, the authentication method and
+name of authenticated identities, like "api-key:analyst" or "client-certificate:analyst".
+Each role permits operations, like "search" or "how", and DATA_SOURCE codes.
+AuthorizedEntityEngine enforces the policy on every Senzing call: it refuses operations the
+caller's roles do not permit and removes records of other data sources from the results,
+so they reach neither the API, nor the console, nor the chat model.
+*/
+package authorization
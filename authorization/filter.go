@@ -0,0 +1,225 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Members of a Senzing entity derived from all of its records.  When a record is hidden, they are removed,
+// because they may repeat the hidden record's values.
+var derivedEntityMembers = []string{"ENTITY_NAME", "FEATURES"}
+
+// Members of a related entity that explain how it relates to the resolved entity.  The records behind the
+// relationship are not listed, so they are removed when a record of either entity is hidden.
+var relationshipMembers = []string{"MATCH_INFO", "MATCH_KEY"}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Refuse a how document that shows any record the caller may not see.
+func (permissions *Permissions) checkHowDocument(howJSON string) (string, error) {
+	if permissions.dataSources[Wildcard] {
+		return howJSON, nil
+	}
+
+	document, err := decodeDocument(howJSON)
+	if err != nil {
+		return "", err
+	}
+
+	for _, dataSource := range dataSources(document) {
+		if !permissions.PermitsDataSource(dataSource) {
+			return "", wraperror.Errorf(senzingchatservice.ErrForbidden,
+				"%s may not see how an entity with %s records resolved", permissions.caller, dataSource)
+		}
+	}
+
+	return howJSON, nil
+}
+
+// Filter the resolved entity and related entities of a GetEntityByEntityID document.
+func (permissions *Permissions) filterEntityDocument(entityJSON string) (string, error) {
+	if permissions.dataSources[Wildcard] {
+		return entityJSON, nil
+	}
+
+	document, err := decodeDocument(entityJSON)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedEntity, _ := document["RESOLVED_ENTITY"].(map[string]any)
+
+	permitted, resolvedHidden := permissions.filterEntity(resolvedEntity)
+	if !permitted {
+		return "", wraperror.Errorf(senzingchatservice.ErrForbidden,
+			"%s may not see records of the entity", permissions.caller)
+	}
+
+	if relatedEntities, ok := document["RELATED_ENTITIES"].([]any); ok {
+		permittedEntities := []any{}
+
+		for _, relatedEntity := range relatedEntities {
+			entity, _ := relatedEntity.(map[string]any)
+
+			permitted, hidden := permissions.filterEntity(entity)
+			if !permitted {
+				continue
+			}
+
+			if hidden || resolvedHidden {
+				for _, member := range relationshipMembers {
+					delete(entity, member)
+				}
+			}
+
+			permittedEntities = append(permittedEntities, entity)
+		}
+
+		document["RELATED_ENTITIES"] = permittedEntities
+	}
+
+	return encodeDocument(document)
+}
+
+// Filter the entities of a SearchByAttributes document.
+func (permissions *Permissions) filterSearchDocument(searchJSON string) (string, error) {
+	if permissions.dataSources[Wildcard] {
+		return searchJSON, nil
+	}
+
+	document, err := decodeDocument(searchJSON)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedEntities, _ := document["RESOLVED_ENTITIES"].([]any)
+	permittedEntities := []any{}
+
+	for _, resolvedEntity := range resolvedEntities {
+		searchResult, _ := resolvedEntity.(map[string]any)
+		entity, _ := searchResult["ENTITY"].(map[string]any)
+		entity, _ = entity["RESOLVED_ENTITY"].(map[string]any)
+
+		permitted, hidden := permissions.filterEntity(entity)
+		if !permitted {
+			continue
+		}
+
+		// Feature scores compare the search attributes with the values of every record.
+
+		if matchInfo, ok := searchResult["MATCH_INFO"].(map[string]any); ok && hidden {
+			delete(matchInfo, "FEATURE_SCORES")
+		}
+
+		permittedEntities = append(permittedEntities, searchResult)
+	}
+
+	document["RESOLVED_ENTITIES"] = permittedEntities
+
+	return encodeDocument(document)
+}
+
+// Remove records the caller may not see from an entity's RECORDS and RECORD_SUMMARY.
+// Report whether any permitted record remains and whether any was removed.
+// An entity that lists no records at all is not permitted, since its data sources are unknown.
+func (permissions *Permissions) filterEntity(entity map[string]any) (bool, bool) {
+	var permitted, hidden bool
+
+	for _, member := range []string{"RECORDS", "RECORD_SUMMARY"} {
+		records, ok := entity[member].([]any)
+		if !ok {
+			continue
+		}
+
+		permittedRecords := []any{}
+
+		for _, record := range records {
+			recordMap, _ := record.(map[string]any)
+			dataSource, _ := recordMap["DATA_SOURCE"].(string)
+
+			if permissions.PermitsDataSource(dataSource) {
+				permittedRecords = append(permittedRecords, record)
+				permitted = true
+			} else {
+				hidden = true
+			}
+		}
+
+		entity[member] = permittedRecords
+	}
+
+	if hidden {
+		for _, member := range derivedEntityMembers {
+			delete(entity, member)
+		}
+	}
+
+	return permitted, hidden
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Every DATA_SOURCE code in a document, at any depth.
+func dataSources(value any) []string {
+	var result []string
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, member := range typedValue {
+			if dataSource, ok := member.(string); ok && key == "DATA_SOURCE" {
+				result = append(result, dataSource)
+
+				continue
+			}
+
+			result = append(result, dataSources(member)...)
+		}
+	case []any:
+		for _, member := range typedValue {
+			result = append(result, dataSources(member)...)
+		}
+	}
+
+	return result
+}
+
+// Numbers are kept as json.Number, so entity IDs survive re-encoding exactly.
+func decodeDocument(documentJSON string) (map[string]any, error) {
+	var result map[string]any
+
+	decoder := json.NewDecoder(strings.NewReader(documentJSON))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&result)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Decode")
+	}
+
+	return result, nil
+}
+
+func encodeDocument(document map[string]any) (string, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(document)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Encode")
+	}
+
+	return strings.TrimSpace(buffer.String()), nil
+}
@@ -0,0 +1,45 @@
+package authorization
+
+import (
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Policy is the content of an authorization policy file.
+// Principals are named "<method>:<name>", e.g. "api-key:analyst"; "*" is every authenticated caller.
+type Policy struct {
+	Principals map[string][]string `json:"principals"` // Roles granted to each principal
+	Roles      map[string]Role     `json:"roles"`
+}
+
+// Role is a set of permitted operations and data sources.  "*" permits all of either.
+type Role struct {
+	DataSources []string `json:"data_sources"`
+	Operations  []string `json:"operations"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Operations named in Role.Operations.
+const (
+	OperationHow    = "how"    // How an entity resolved
+	OperationReport = "report" // An entity and its records, e.g. entity_details
+	OperationSearch = "search" // Search by attributes
+	OperationWrite  = "write"  // Add or delete records
+)
+
+// Wildcard permits every operation or data source, or grants roles to every authenticated caller.
+const Wildcard = "*"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("authorization")
+
+var operations = []string{OperationHow, OperationReport, OperationSearch, OperationWrite, Wildcard}
@@ -0,0 +1,92 @@
+/*
+ */
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/audit"
+	"github.com/spf13/cobra"
+)
+
+// AuditCmd represents the audit command.
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Work with the audit file written with --audit-file",
+}
+
+// AuditVerifyCmd represents the audit verify command.
+var AuditVerifyCmd = &cobra.Command{
+	Use:   "verify AUDIT_FILE",
+	Short: "Verify that an audit file has not been tampered with",
+	Long: `Verify checks the hash chain of an audit file and reports the first entry
+that was changed, removed or reordered.  It needs the key the file was
+written with, given by --audit-key-file.
+
+The chain is keyed, so whoever can change the audit file but not read the
+key cannot make a changed file verify.  Whoever holds the key can: keep the
+key file readable only by serve-chat and the auditors.
+
+Entries removed from the end of the file leave a shorter, valid chain.
+To detect that, keep the sequence number and hash that verify prints,
+and compare them with the output of a later verify.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFilename, err := cmd.Flags().GetString(AuditKeyFile.Arg)
+		if err != nil {
+			return wraperror.Errorf(err, "getting '%s' value", AuditKeyFile.Arg)
+		}
+
+		return AuditVerifyAction(os.Stdout, args[0], keyFilename)
+	},
+}
+
+var errNoAuditKeyFile = errors.New("no audit key file")
+
+func init() {
+	AuditCmd.AddCommand(AuditVerifyCmd)
+	AuditVerifyCmd.Flags().String(AuditKeyFile.Arg, option.OsLookupEnvString(AuditKeyFile.Envar, ""),
+		fmt.Sprintf("Path to the key the audit file was written with [%s]", AuditKeyFile.Envar))
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func AuditVerifyAction(out io.Writer, filename string, keyFilename string) error {
+	if len(keyFilename) == 0 {
+		return wraperror.Errorf(errNoAuditKeyFile, "use --%s or %s", AuditKeyFile.Arg, AuditKeyFile.Envar)
+	}
+
+	key, err := audit.LoadKey(keyFilename)
+	if err != nil {
+		return wraperror.Errorf(err, "audit.LoadKey: %s", keyFilename)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return wraperror.Errorf(err, "os.Open: %s", filename)
+	}
+	defer file.Close()
+
+	lastEntry, err := audit.Verify(file, key)
+	if err != nil {
+		return wraperror.Errorf(err, "audit.Verify: %s", filename)
+	}
+
+	if lastEntry == nil {
+		_, err = fmt.Fprintf(out, "%s is empty\n", filename)
+	} else {
+		_, err = fmt.Fprintf(out, "%s verified: %d entries; the last hash is %s\n",
+			filename, lastEntry.Sequence, lastEntry.Hash)
+	}
+
+	if err != nil {
+		return wraperror.Errorf(err, "printing result")
+	}
+
+	return nil
+}
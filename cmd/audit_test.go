@@ -0,0 +1,121 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/audit"
+	"github.com/senzing-garage/serve-chat/cmd"
+	"github.com/stretchr/testify/require"
+)
+
+var auditKey = []byte("0123456789abcdef0123456789abcdef")
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func Test_AuditVerifyAction(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+	auditFile, lastHash := writeAuditLog(test, directory)
+
+	var buffer bytes.Buffer
+
+	err := cmd.AuditVerifyAction(&buffer, auditFile, writeFile(test, directory, "audit.key", auditKey))
+	require.NoError(test, err)
+	require.Equal(test, auditFile+" verified: 2 entries; the last hash is "+lastHash+"\n", buffer.String())
+}
+
+func Test_AuditVerifyAction_edited(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+	auditFile, _ := writeAuditLog(test, directory)
+	content, err := os.ReadFile(auditFile)
+	require.NoError(test, err)
+	require.NoError(test, os.WriteFile(auditFile, bytes.Replace(content, []byte("1001"), []byte("1009"), 1), 0o600))
+
+	var buffer bytes.Buffer
+
+	err = cmd.AuditVerifyAction(&buffer, auditFile, writeFile(test, directory, "audit.key", auditKey))
+	require.ErrorContains(test, err, "line 1")
+	require.Empty(test, buffer.String())
+}
+
+func Test_AuditVerifyAction_badKey(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+	auditFile, _ := writeAuditLog(test, directory)
+
+	for keyFile, message := range map[string]string{
+		writeFile(test, directory, "wrong.key", bytes.Repeat([]byte("k"), 32)): "line 1: hash does not match",
+		writeFile(test, directory, "short.key", []byte("secret")):              "it needs at least 32",
+		filepath.Join(directory, "missing.key"):                                "missing.key",
+		"":                                                                     "no audit key file",
+	} {
+		var buffer bytes.Buffer
+
+		err := cmd.AuditVerifyAction(&buffer, auditFile, keyFile)
+		require.ErrorContains(test, err, message)
+		require.Empty(test, buffer.String(), message)
+	}
+}
+
+func Test_AuditVerifyAction_emptyOrMissingFile(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+	keyFile := writeFile(test, directory, "audit.key", auditKey)
+	emptyFile := writeFile(test, directory, "empty.jsonl", []byte{})
+
+	var buffer bytes.Buffer
+
+	err := cmd.AuditVerifyAction(&buffer, emptyFile, keyFile)
+	require.NoError(test, err)
+	require.Equal(test, emptyFile+" is empty\n", buffer.String())
+
+	buffer.Reset()
+
+	err = cmd.AuditVerifyAction(&buffer, filepath.Join(directory, "missing.jsonl"), keyFile)
+	require.ErrorContains(test, err, "missing.jsonl")
+	require.Empty(test, buffer.String())
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// A Log of two entries, and the hash of the last.
+func writeAuditLog(test *testing.T, directory string) (string, string) {
+	test.Helper()
+
+	result := filepath.Join(directory, "audit.jsonl")
+	auditLog, err := audit.OpenLog(result, auditKey)
+	require.NoError(test, err)
+
+	var entry *audit.Entry
+
+	for _, entityID := range []int64{1001, 1002} {
+		entry = &audit.Entry{ //nolint:exhaustruct
+			EntityID:  entityID,
+			EntityIDs: []int64{entityID},
+			Operation: audit.OperationGetEntityByEntityID,
+			Principal: "api-key:analyst",
+		}
+		require.NoError(test, auditLog.Append(entry))
+	}
+
+	require.NoError(test, auditLog.Close())
+
+	return result, entry.Hash
+}
+
+func writeFile(test *testing.T, directory string, name string, content []byte) string {
+	test.Helper()
+
+	result := filepath.Join(directory, name)
+	require.NoError(test, os.WriteFile(result, content, 0o600))
+
+	return result
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/senzing-garage/go-cmdhelping/option"
 	"github.com/senzing-garage/serve-chat/cmd"
+	"github.com/stretchr/testify/require"
 )
 
 // ----------------------------------------------------------------------------
@@ -19,6 +21,24 @@ func Test_Execute(test *testing.T) {
 	cmd.Execute()
 }
 
+func Test_NewHTTPServer(test *testing.T) {
+	require.NoError(test, cmd.RootCmd.ParseFlags([]string{"--enable-metrics"}))
+	test.Cleanup(func() { _ = cmd.RootCmd.Flags().Set(cmd.EnableMetrics.Arg, "false") })
+	cmd.PreRun(cmd.RootCmd, []string{})
+
+	httpServer := cmd.NewHTTPServer("{}")
+	require.True(test, httpServer.EnableMetrics)
+}
+
+func Test_RunE_withoutEntityEngine(test *testing.T) {
+	require.NoError(test, cmd.RootCmd.ParseFlags([]string{"--enable-all"}))
+	test.Cleanup(func() { _ = cmd.RootCmd.Flags().Set(option.EnableAll.Arg, "false") })
+	cmd.PreRun(cmd.RootCmd, []string{})
+
+	err := cmd.RunE(cmd.RootCmd, []string{})
+	require.ErrorContains(test, err, "--replay-file")
+}
+
 // func Test_Execute_completion(test *testing.T) {
 // 	test.Parallel()
 
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"github.com/senzing-garage/go-cmdhelping/option"
+	"github.com/senzing-garage/go-cmdhelping/option/optiontype"
+)
+
+// ----------------------------------------------------------------------------
+// Context variables specific to serve-chat
+// ----------------------------------------------------------------------------
+
+var APIKeyFile = option.ContextVariable{
+	Arg:     "api-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_API_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_API_KEY_FILE",
+	Help:    "Path to a JSON object mapping SHA-256 hashes of API keys to their holders; keys are sent in X-API-Key [%s]",
+	Type:    optiontype.String,
+}
+
+var AuditAttributes = option.ContextVariable{
+	Arg:     "audit-attributes",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUDIT_ATTRIBUTES", "hash"),
+	Envar:   "SENZING_TOOLS_AUDIT_ATTRIBUTES",
+	Help:    "How search attributes are written to the audit file: \"hash\", \"redact\" or \"verbatim\" [%s]",
+	Type:    optiontype.String,
+}
+
+var AuditFile = option.ContextVariable{
+	Arg:     "audit-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUDIT_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUDIT_FILE",
+	Help:    "Path to a hash-chained JSONL file to which every Senzing call, and its caller, is appended [%s]",
+	Type:    optiontype.String,
+}
+
+var AuditKeyFile = option.ContextVariable{
+	Arg:     "audit-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUDIT_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUDIT_KEY_FILE",
+	Help:    "Path to a secret of at least 32 bytes, kept apart from the audit file, that keys its hash chain [%s]",
+	Type:    optiontype.String,
+}
+
+var AuthorizationPolicyFile = option.ContextVariable{
+	Arg:     "authorization-policy-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_AUTHORIZATION_POLICY_FILE", ""),
+	Envar:   "SENZING_TOOLS_AUTHORIZATION_POLICY_FILE",
+	Help:    "Path to a JSON policy granting roles to principals, named like \"api-key:analyst\" [%s]",
+	Type:    optiontype.String,
+}
+
+var ChatURLRoutePrefix = option.ContextVariable{
+	Arg:     "chat-url-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CHAT_URL_ROUTE_PREFIX", "chat"),
+	Envar:   "SENZING_TOOLS_CHAT_URL_ROUTE_PREFIX",
+	Help:    "Path under which the Senzing Chat API is served, e.g. \"api/v2/chat\" [%s]",
+	Type:    optiontype.String,
+}
+
+var ClientIdentityFile = option.ContextVariable{
+	Arg:     "client-identity-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_CLIENT_IDENTITY_FILE", ""),
+	Envar:   "SENZING_TOOLS_CLIENT_IDENTITY_FILE",
+	Help:    "Path to a JSON object mapping client certificate subjects to identity names [%s]",
+	Type:    optiontype.String,
+}
+
+var EnableMetrics = option.ContextVariable{
+	Arg:     "enable-metrics",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_ENABLE_METRICS", false),
+	Envar:   "SENZING_TOOLS_ENABLE_METRICS",
+	Help:    "Serve Prometheus metrics at /metrics [%s]",
+	Type:    optiontype.Bool,
+}
+
+var JWKSFile = option.ContextVariable{
+	Arg:     "jwks-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_JWKS_FILE", ""),
+	Envar:   "SENZING_TOOLS_JWKS_FILE",
+	Help:    "Path to a JSON Web Key Set of the keys that sign JWT bearer tokens [%s]",
+	Type:    optiontype.String,
+}
+
+var JWTAudience = option.ContextVariable{
+	Arg:     "jwt-audience",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_JWT_AUDIENCE", ""),
+	Envar:   "SENZING_TOOLS_JWT_AUDIENCE",
+	Help:    "Audience that JWT bearer tokens must name in their \"aud\" claim [%s]",
+	Type:    optiontype.String,
+}
+
+var JWTIssuer = option.ContextVariable{
+	Arg:     "jwt-issuer",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_JWT_ISSUER", ""),
+	Envar:   "SENZING_TOOLS_JWT_ISSUER",
+	Help:    "Issuer URL that JWT bearer tokens must name; without --jwks-file, its keys are discovered [%s]",
+	Type:    optiontype.String,
+}
+
+var OTLPTraceEndpoint = option.ContextVariable{
+	Arg:     "otlp-trace-endpoint",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_OTLP_TRACE_ENDPOINT", ""),
+	Envar:   "SENZING_TOOLS_OTLP_TRACE_ENDPOINT",
+	Help:    "host:port of the OTLP/gRPC trace collector; if empty, OTEL_EXPORTER_OTLP_* variables apply [%s]",
+	Type:    optiontype.String,
+}
+
+var OTLPTraceInsecure = option.ContextVariable{
+	Arg:     "otlp-trace-insecure",
+	Default: option.OsLookupEnvBool("SENZING_TOOLS_OTLP_TRACE_INSECURE", false),
+	Envar:   "SENZING_TOOLS_OTLP_TRACE_INSECURE",
+	Help:    "Connect to the OTLP trace collector without TLS [%s]",
+	Type:    optiontype.Bool,
+}
+
+var PromptDir = option.ContextVariable{
+	Arg:     "prompt-dir",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_PROMPT_DIR", ""),
+	Envar:   "SENZING_TOOLS_PROMPT_DIR",
+	Help:    "Path to a directory of prompt templates that override the embedded defaults [%s]",
+	Type:    optiontype.String,
+}
+
+var ServerCACertificateFile = option.ContextVariable{
+	Arg:     "server-ca-certificate-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SERVER_CA_CERTIFICATE_FILE", ""),
+	Envar:   "SENZING_TOOLS_SERVER_CA_CERTIFICATE_FILE",
+	Help:    "Path to a PEM bundle of CAs for client certificates; enables mutual TLS [%s]",
+	Type:    optiontype.String,
+}
+
+var ServerCertificateFile = option.ContextVariable{
+	Arg:     "server-certificate-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SERVER_CERTIFICATE_FILE", ""),
+	Envar:   "SENZING_TOOLS_SERVER_CERTIFICATE_FILE",
+	Help:    "Path to the server's PEM certificate; enables HTTPS.  Reloaded when changed [%s]",
+	Type:    optiontype.String,
+}
+
+var ServerKeyFile = option.ContextVariable{
+	Arg:     "server-key-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SERVER_KEY_FILE", ""),
+	Envar:   "SENZING_TOOLS_SERVER_KEY_FILE",
+	Help:    "Path to the server's PEM private key [%s]",
+	Type:    optiontype.String,
+}
+
+var ServerKeyPassphrase = option.ContextVariable{
+	Arg:     "server-key-passphrase",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SERVER_KEY_PASSPHRASE", ""),
+	Envar:   "SENZING_TOOLS_SERVER_KEY_PASSPHRASE",
+	Help:    "Passphrase of an encrypted server key [%s]",
+	Type:    optiontype.String,
+}
+
+var ShutdownGracePeriodInSeconds = option.ContextVariable{
+	Arg:     "shutdown-grace-period-in-seconds",
+	Default: option.OsLookupEnvInt("SENZING_TOOLS_SHUTDOWN_GRACE_PERIOD_IN_SECONDS", 25),
+	Envar:   "SENZING_TOOLS_SHUTDOWN_GRACE_PERIOD_IN_SECONDS",
+	Help:    "Seconds to let in-flight requests finish after SIGTERM or SIGINT; 0 waits indefinitely [%s]",
+	Type:    optiontype.Int,
+}
+
+var RecordFile = option.ContextVariable{
+	Arg:     "record-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_RECORD_FILE", ""),
+	Envar:   "SENZING_TOOLS_RECORD_FILE",
+	Help:    "Path to a JSONL file to which Senzing calls are appended [%s]",
+	Type:    optiontype.String,
+}
+
+var ReplayFile = option.ContextVariable{
+	Arg:     "replay-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_REPLAY_FILE", ""),
+	Envar:   "SENZING_TOOLS_REPLAY_FILE",
+	Help:    "Path to a JSONL file of recorded Senzing calls to serve instead of the Senzing engine [%s]",
+	Type:    optiontype.String,
+}
+
+var SwaggerURLRoutePrefix = option.ContextVariable{
+	Arg:     "swagger-url-route-prefix",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_SWAGGER_URL_ROUTE_PREFIX", "swagger"),
+	Envar:   "SENZING_TOOLS_SWAGGER_URL_ROUTE_PREFIX",
+	Help:    "Path under which Swagger UI is served [%s]",
+	Type:    optiontype.String,
+}
+
+var TLSMinimumVersion = option.ContextVariable{
+	Arg:     "tls-minimum-version",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TLS_MINIMUM_VERSION", "1.2"),
+	Envar:   "SENZING_TOOLS_TLS_MINIMUM_VERSION",
+	Help:    "Minimum TLS version accepted: 1.2 or 1.3 [%s]",
+	Type:    optiontype.String,
+}
+
+var TraceExporter = option.ContextVariable{
+	Arg:     "trace-exporter",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TRACE_EXPORTER", "none"),
+	Envar:   "SENZING_TOOLS_TRACE_EXPORTER",
+	Help:    "Where to export OpenTelemetry traces: none, otlp, stdout or file [%s]",
+	Type:    optiontype.String,
+}
+
+var TraceFile = option.ContextVariable{
+	Arg:     "trace-file",
+	Default: option.OsLookupEnvString("SENZING_TOOLS_TRACE_FILE", ""),
+	Envar:   "SENZING_TOOLS_TRACE_FILE",
+	Help:    "Path to which spans are appended when --trace-exporter is file [%s]",
+	Type:    optiontype.String,
+}
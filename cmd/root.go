@@ -4,7 +4,10 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/senzing-garage/go-cmdhelping/cmdhelper"
@@ -15,8 +18,10 @@ import (
 	"github.com/senzing-garage/go-observing/observer"
 	"github.com/senzing-garage/serve-chat/httpserver"
 	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/senzing-garage/serve-chat/tracing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
 )
 
@@ -49,10 +54,37 @@ var ContextVariablesForMultiPlatform = []option.ContextVariable{
 	option.ObserverOrigin,
 	option.ObserverURL,
 	option.ServerAddress,
+	APIKeyFile,
+	AuditAttributes,
+	AuditFile,
+	AuditKeyFile,
+	AuthorizationPolicyFile,
+	ChatURLRoutePrefix,
+	ClientIdentityFile,
+	EnableMetrics,
+	JWKSFile,
+	JWTAudience,
+	JWTIssuer,
+	OTLPTraceEndpoint,
+	OTLPTraceInsecure,
+	PromptDir,
+	RecordFile,
+	ReplayFile,
+	ServerCACertificateFile,
+	ServerCertificateFile,
+	ServerKeyFile,
+	ServerKeyPassphrase,
+	ShutdownGracePeriodInSeconds,
+	SwaggerURLRoutePrefix,
+	TLSMinimumVersion,
+	TraceExporter,
+	TraceFile,
 }
 
 var ContextVariables = append(ContextVariablesForMultiPlatform, ContextVariablesForOsArch...)
 
+var errNoEntityEngine = errors.New("no Senzing engine")
+
 // ----------------------------------------------------------------------------
 // Command
 // ----------------------------------------------------------------------------
@@ -89,7 +121,18 @@ func PreRun(cobraCommand *cobra.Command, args []string) {
 func RunE(_ *cobra.Command, _ []string) error {
 	var err error
 
-	ctx := context.Background()
+	// Cancelling ctx on SIGINT or SIGTERM starts a graceful shutdown of the HTTP server.
+	// Once it starts, the default signal behaviour is restored, so a second signal exits at once.
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	context.AfterFunc(ctx, stop)
+
+	err = checkEntityEngine()
+	if err != nil {
+		return err
+	}
 
 	senzingEngineConfigurationJSON, err := settings.BuildAndVerifySettings(ctx, viper.GetViper())
 	if err != nil {
@@ -114,27 +157,32 @@ func RunE(_ *cobra.Command, _ []string) error {
 
 	observers := []observer.Observer{}
 
+	// Export traces and propagate W3C trace context.
+
+	otel.SetTextMapPropagator(tracing.NewPropagator())
+
+	tracerProvider, err := tracing.NewTracerProvider(ctx, tracing.Options{
+		Exporter:     viper.GetString(TraceExporter.Arg),
+		File:         viper.GetString(TraceFile.Arg),
+		OTLPEndpoint: viper.GetString(OTLPTraceEndpoint.Arg),
+		OTLPInsecure: viper.GetBool(OTLPTraceInsecure.Arg),
+		ServiceName:  Use,
+	})
+	if err != nil {
+		return wraperror.Errorf(err, "NewTracerProvider")
+	}
+
 	// Create object and Serve.
 
-	httpServer := &httpserver.BasicHTTPServer{
-		AvoidServing:          viper.GetBool(option.AvoidServe.Arg),
-		ChatURLRoutePrefix:    "chat",
-		EnableAll:             viper.GetBool(option.EnableAll.Arg),
-		EnableSenzingChatAPI:  viper.GetBool(option.EnableSenzingChatAPI.Arg),
-		EnableSwaggerUI:       viper.GetBool(option.EnableSwaggerUI.Arg),
-		GrpcDialOptions:       grpcDialOptions,
-		GrpcTarget:            grpcTarget,
-		LogLevelName:          viper.GetString(option.LogLevel.Arg),
-		ObserverOrigin:        viper.GetString(option.ObserverOrigin.Arg),
-		Observers:             observers,
-		OpenAPISpecification:  senzingchatservice.OpenAPISpecificationJSON,
-		ReadHeaderTimeout:     ReadHeaderTimeoutInSeconds * time.Second,
-		Setting:               senzingEngineConfigurationJSON,
-		SenzingInstanceName:   viper.GetString(option.CoreInstanceName.Arg),
-		SenzingVerboseLogging: viper.GetInt64(option.CoreLogLevel.Arg),
-		ServerAddress:         viper.GetString(option.ServerAddress.Arg),
-		ServerPort:            viper.GetInt(option.HTTPPort.Arg),
-		SwaggerURLRoutePrefix: "swagger",
+	httpServer := NewHTTPServer(senzingEngineConfigurationJSON)
+	httpServer.GrpcDialOptions = grpcDialOptions
+	httpServer.GrpcTarget = grpcTarget
+	httpServer.Observers = observers
+
+	if tracerProvider != nil {
+		httpServer.TracerProvider = tracerProvider
+
+		defer func() { _ = tracerProvider.Shutdown(context.WithoutCancel(ctx)) }()
 	}
 
 	err = httpServer.Serve(ctx)
@@ -142,6 +190,54 @@ func RunE(_ *cobra.Command, _ []string) error {
 	return wraperror.Errorf(err, wraperror.NoMessage)
 }
 
+/*
+The NewHTTPServer function builds the HTTP server configured by the command line options and environment variables.
+
+Input
+  - settings: The Senzing engine configuration JSON.
+
+Output
+  - The HTTP server.  gRPC, observers and tracing, which need more than an option value, are left for RunE to add.
+*/
+func NewHTTPServer(settings string) *httpserver.BasicHTTPServer {
+	return &httpserver.BasicHTTPServer{
+		APIKeyFile:              viper.GetString(APIKeyFile.Arg),
+		AuditAttributes:         viper.GetString(AuditAttributes.Arg),
+		AuditFile:               viper.GetString(AuditFile.Arg),
+		AuditKeyFile:            viper.GetString(AuditKeyFile.Arg),
+		AuthorizationPolicyFile: viper.GetString(AuthorizationPolicyFile.Arg),
+		AvoidServing:            viper.GetBool(option.AvoidServe.Arg),
+		ChatURLRoutePrefix:      viper.GetString(ChatURLRoutePrefix.Arg),
+		ClientIdentityFile:      viper.GetString(ClientIdentityFile.Arg),
+		EnableAll:               viper.GetBool(option.EnableAll.Arg),
+		EnableMetrics:           viper.GetBool(EnableMetrics.Arg),
+		EnableSenzingChatAPI:    viper.GetBool(option.EnableSenzingChatAPI.Arg),
+		EnableSwaggerUI:         viper.GetBool(option.EnableSwaggerUI.Arg),
+		JWKSFile:                viper.GetString(JWKSFile.Arg),
+		JWTAudience:             viper.GetString(JWTAudience.Arg),
+		JWTIssuer:               viper.GetString(JWTIssuer.Arg),
+		LogLevelName:            viper.GetString(option.LogLevel.Arg),
+		ObserverOrigin:          viper.GetString(option.ObserverOrigin.Arg),
+		OpenAPISpecification:    senzingchatservice.OpenAPISpecificationJSON,
+		PromptDir:               viper.GetString(PromptDir.Arg),
+		ReadHeaderTimeout:       ReadHeaderTimeoutInSeconds * time.Second,
+		RecordFile:              viper.GetString(RecordFile.Arg),
+		ReplayFile:              viper.GetString(ReplayFile.Arg),
+		Setting:                 settings,
+		SenzingInstanceName:     viper.GetString(option.CoreInstanceName.Arg),
+		SenzingVerboseLogging:   viper.GetInt64(option.CoreLogLevel.Arg),
+		ServerAddress:           viper.GetString(option.ServerAddress.Arg),
+		ServerCACertificateFile: viper.GetString(ServerCACertificateFile.Arg),
+		ServerCertificateFile:   viper.GetString(ServerCertificateFile.Arg),
+		ServerKeyFile:           viper.GetString(ServerKeyFile.Arg),
+		ServerKeyPassphrase:     viper.GetString(ServerKeyPassphrase.Arg),
+		ServerPort:              viper.GetInt(option.HTTPPort.Arg),
+		ShutdownGracePeriod:     time.Duration(viper.GetInt(ShutdownGracePeriodInSeconds.Arg)) * time.Second,
+		SwaggerURLRoutePrefix:   viper.GetString(SwaggerURLRoutePrefix.Arg),
+		TLSMinimumVersion:       viper.GetString(TLSMinimumVersion.Arg),
+	}
+}
+
 // Used in construction of cobra.Command.
 func Version() string {
 	return cmdhelper.Version(githubVersion, githubIteration)
@@ -155,3 +251,15 @@ func Version() string {
 func init() {
 	cmdhelper.Init(RootCmd, ContextVariables)
 }
+
+// This build cannot create a Senzing engine, so the chat API can only answer from a replay.
+// Refuse to start a server whose chat API would answer every call with 501.
+func checkEntityEngine() error {
+	chatEnabled := viper.GetBool(option.EnableAll.Arg) || viper.GetBool(option.EnableSenzingChatAPI.Arg)
+	if !chatEnabled || len(viper.GetString(ReplayFile.Arg)) > 0 {
+		return nil
+	}
+
+	return wraperror.Errorf(errNoEntityEngine, "the chat API is enabled, but this build cannot create a Senzing "+
+		"engine; use --%s to serve recorded Senzing calls", ReplayFile.Arg)
+}
@@ -0,0 +1,68 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/audit"
+)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Append every Senzing call to the AuditFile.  The audit wraps authorization, so it records what callers were
+// refused and which entities they were shown.
+func (httpServer *BasicHTTPServer) setupAudit() (func(), error) {
+	closeAuditFile := func() {}
+
+	if len(httpServer.AuditFile) == 0 || httpServer.entityEngine == nil {
+		return closeAuditFile, nil
+	}
+
+	err := audit.CheckAttributes(httpServer.AuditAttributes)
+	if err != nil {
+		return closeAuditFile, wraperror.Errorf(err, "CheckAttributes")
+	}
+
+	// Without a secret key, whoever can write the AuditFile could recompute its hash chain.
+
+	if len(httpServer.AuditKeyFile) == 0 {
+		return closeAuditFile, wraperror.Errorf(errForPackage, "an audit file needs an audit key file")
+	}
+
+	key, err := audit.LoadKey(httpServer.AuditKeyFile)
+	if err != nil {
+		return closeAuditFile, wraperror.Errorf(err, "LoadKey")
+	}
+
+	auditLog, err := audit.OpenLog(httpServer.AuditFile, key)
+	if err != nil {
+		return closeAuditFile, wraperror.Errorf(err, "OpenLog")
+	}
+
+	closeAuditFile = func() { _ = auditLog.Close() }
+	httpServer.entityEngine = &audit.AuditedEntityEngine{
+		Attributes:   httpServer.AuditAttributes,
+		EntityEngine: httpServer.entityEngine,
+		Log:          auditLog,
+	}
+
+	return closeAuditFile, nil
+}
+
+// Pass the conversation a chat client names in the X-Conversation-ID header on to the audit.
+func (httpServer *BasicHTTPServer) conversationHandler(next http.Handler) http.Handler {
+	if len(httpServer.AuditFile) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		conversationID := request.Header.Get(audit.HeaderConversationID)
+		if len(conversationID) > 0 {
+			request = request.WithContext(audit.NewContext(request.Context(), conversationID))
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,113 @@
+package httpserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/audit"
+	"github.com/senzing-garage/serve-chat/authentication"
+	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPServerImpl_Serve_audit(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	directory := test.TempDir()
+	apiKeyFile := filepath.Join(directory, "api-keys.json")
+	apiKeys := fmt.Sprintf(`{%q: "analyst"}`, authentication.HashAPIKey("analyst-key"))
+	require.NoError(test, os.WriteFile(apiKeyFile, []byte(apiKeys), 0o600))
+
+	auditFile := filepath.Join(directory, "audit.jsonl")
+	httpServer := &httpserver.BasicHTTPServer{
+		APIKeyFile:           apiKeyFile,
+		AuditFile:            auditFile,
+		AuditKeyFile:         writeAuditKey(test, directory),
+		EnableSenzingChatAPI: true,
+		EntityEngine:         &healthEntityEngine{err: nil},
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	getWhenReady(test, baseURL+"/livez")
+
+	request := newRequest(test, baseURL+"/chat/entity_details?entity_id=1")
+	request.Header.Set(authentication.HeaderAPIKey, "analyst-key")
+	request.Header.Set(audit.HeaderConversationID, "conversation-1")
+	require.Equal(test, http.StatusOK, doRequest(test, request))
+
+	request = newRequest(test, baseURL+"/site/entity/2")
+	request.Header.Set(authentication.HeaderAPIKey, "analyst-key")
+	require.Equal(test, http.StatusOK, doRequest(test, request))
+
+	cancel()
+	require.NoError(test, <-served)
+
+	file, err := os.Open(auditFile)
+	require.NoError(test, err)
+
+	defer file.Close()
+
+	lastEntry, err := audit.Verify(file, auditKey)
+	require.NoError(test, err)
+	require.Equal(test, int64(3), lastEntry.Sequence) // The entity page also shows how the entity resolved.
+	require.Equal(test, audit.OperationHowEntityByEntityID, lastEntry.Operation)
+	require.Equal(test, "api-key:analyst", lastEntry.Principal)
+	require.Equal(test, int64(2), lastEntry.EntityID)
+	require.Empty(test, lastEntry.ConversationID)
+
+	content, err := os.ReadFile(auditFile)
+	require.NoError(test, err)
+	require.Contains(test, string(content), `"conversation_id":"conversation-1"`)
+}
+
+func TestHTTPServerImpl_Serve_badAuditAttributes(test *testing.T) {
+	test.Parallel()
+	directory := test.TempDir()
+	httpServer := &httpserver.BasicHTTPServer{
+		AuditAttributes: "encrypt",
+		AuditFile:       filepath.Join(directory, "audit.jsonl"),
+		AuditKeyFile:    writeAuditKey(test, directory),
+		AvoidServing:    true,
+		EntityEngine:    &healthEntityEngine{err: nil},
+	}
+	require.Error(test, httpServer.Serve(test.Context()))
+}
+
+func TestHTTPServerImpl_Serve_auditWithoutKey(test *testing.T) {
+	test.Parallel()
+	httpServer := &httpserver.BasicHTTPServer{
+		AuditFile:    filepath.Join(test.TempDir(), "audit.jsonl"),
+		AvoidServing: true,
+		EntityEngine: &healthEntityEngine{err: nil},
+	}
+	require.Error(test, httpServer.Serve(test.Context()))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+var auditKey = []byte("0123456789abcdef0123456789abcdef")
+
+func writeAuditKey(test *testing.T, directory string) string {
+	test.Helper()
+
+	result := filepath.Join(directory, "audit.key")
+	require.NoError(test, os.WriteFile(result, auditKey, 0o600))
+
+	return result
+}
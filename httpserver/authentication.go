@@ -0,0 +1,187 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ogen-go/ogen/middleware"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/authentication"
+	"github.com/senzing-garage/serve-chat/authorization"
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Load the configured API keys and JWT signing keys.  With no authenticators, requests are not authenticated.
+func (httpServer *BasicHTTPServer) setupAuthentication(ctx context.Context) error {
+	httpServer.authenticators = nil
+
+	if len(httpServer.APIKeyFile) > 0 {
+		apiKeyAuthenticator := &authentication.APIKeyAuthenticator{
+			APIKeyFile: httpServer.APIKeyFile,
+		}
+
+		err := apiKeyAuthenticator.Load(ctx)
+		if err != nil {
+			return wraperror.Errorf(err, "Load API keys")
+		}
+
+		httpServer.authenticators = append(httpServer.authenticators, apiKeyAuthenticator)
+	}
+
+	if len(httpServer.JWKSFile) > 0 || len(httpServer.JWTIssuer) > 0 {
+		jwtAuthenticator := &authentication.JWTAuthenticator{
+			Audience: httpServer.JWTAudience,
+			Issuer:   httpServer.JWTIssuer,
+			JWKSFile: httpServer.JWKSFile,
+		}
+
+		err := jwtAuthenticator.Load(ctx)
+		if err != nil {
+			return wraperror.Errorf(err, "Load JWT signing keys")
+		}
+
+		httpServer.authenticators = append(httpServer.authenticators, jwtAuthenticator)
+	}
+
+	httpServer.authenticators = append(httpServer.authenticators, httpServer.Authenticators...)
+
+	return nil
+}
+
+// Enforce the authorization policy on every Senzing call, from the chat API, the console or the chat model.
+// The policy grants roles to authenticated callers, so it requires a way to authenticate them.
+func (httpServer *BasicHTTPServer) setupAuthorization() error {
+	if len(httpServer.AuthorizationPolicyFile) == 0 {
+		return nil
+	}
+
+	if len(httpServer.authenticators) == 0 && len(httpServer.ServerCACertificateFile) == 0 {
+		return wraperror.Errorf(errForPackage, "an authorization policy requires API keys, JWTs or client certificates")
+	}
+
+	policy, err := authorization.LoadPolicy(httpServer.AuthorizationPolicyFile)
+	if err != nil {
+		return wraperror.Errorf(err, "LoadPolicy")
+	}
+
+	if httpServer.entityEngine != nil {
+		httpServer.entityEngine = &authorization.AuthorizedEntityEngine{
+			EntityEngine: httpServer.entityEngine,
+			Policy:       policy,
+		}
+	}
+
+	return nil
+}
+
+// Identify the caller.  A verified client certificate suffices; otherwise the first authenticator
+// whose credentials the request carries decides.
+func (httpServer *BasicHTTPServer) authenticate(request *http.Request) (*identity.Identity, error) {
+	if result, ok := identity.FromContext(request.Context()); ok {
+		return result, nil
+	}
+
+	for _, authenticator := range httpServer.authenticators {
+		result, err := authenticator.Authenticate(request)
+		if errors.Is(err, authentication.ErrNoCredentials) {
+			continue
+		}
+
+		if err != nil {
+			return nil, wraperror.Errorf(err, "Authenticate")
+		}
+
+		return result, nil
+	}
+
+	return nil, wraperror.Errorf(authentication.ErrNoCredentials, "authenticate")
+}
+
+// The security requirements added to the served OpenAPI specification, so Swagger UI offers to send credentials.
+// Any one of the configured schemes suffices.
+func (httpServer *BasicHTTPServer) openAPISecurity() []map[string][]string {
+	var result []map[string][]string
+
+	if len(httpServer.APIKeyFile) > 0 {
+		result = append(result, map[string][]string{authentication.SecuritySchemeAPIKey: {}})
+	}
+
+	if len(httpServer.JWKSFile) > 0 || len(httpServer.JWTIssuer) > 0 {
+		result = append(result, map[string][]string{authentication.SecuritySchemeBearer: {}})
+	}
+
+	return result
+}
+
+// --- Middleware -------------------------------------------------------------
+
+// Reject chat API requests from unauthenticated callers with 401; pass the caller's Identity on to the operation.
+func (httpServer *BasicHTTPServer) authenticationMiddleware(
+	request middleware.Request,
+	next middleware.Next,
+) (middleware.Response, error) {
+	caller, err := httpServer.authenticate(request.Raw)
+	if err != nil {
+		senzingchatservice.Log(
+			request.Context,
+			senzingchatservice.MessageAuthenticationFailed,
+			request.Raw.Method,
+			request.Raw.URL.Path,
+			err,
+		)
+
+		return middleware.Response{}, &ogenerrors.SecurityError{
+			OperationContext: ogenerrors.OperationContext{
+				Name: request.OperationName,
+				ID:   request.OperationID,
+			},
+			Security: "",
+			Err:      errUnauthorized,
+		}
+	}
+
+	request.SetContext(identity.NewContext(request.Context, caller))
+
+	return next(request)
+}
+
+// Reject requests from unauthenticated callers with 401; pass the caller's Identity on to next.
+func (httpServer *BasicHTTPServer) authenticationHandler(next http.Handler) http.Handler {
+	if len(httpServer.authenticators) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		caller, err := httpServer.authenticate(request)
+		if err != nil {
+			senzingchatservice.Log(
+				request.Context(),
+				senzingchatservice.MessageAuthenticationFailed,
+				request.Method,
+				request.URL.Path,
+				err,
+			)
+
+			if len(httpServer.JWKSFile) > 0 || len(httpServer.JWTIssuer) > 0 {
+				writer.Header().Set("WWW-Authenticate", `Bearer realm="serve-chat"`)
+			}
+
+			senzingchatservice.WriteError(
+				writer,
+				http.StatusUnauthorized,
+				senzingchatservice.MessageAuthenticationFailed,
+			)
+
+			return
+		}
+
+		next.ServeHTTP(writer, request.WithContext(identity.NewContext(request.Context(), caller)))
+	})
+}
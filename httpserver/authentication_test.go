@@ -0,0 +1,168 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/authentication"
+	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPServerImpl_Serve_apiKey(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	apiKeyFile := filepath.Join(test.TempDir(), "api-keys.json")
+	apiKeys := fmt.Sprintf(`{%q: "analyst"}`, authentication.HashAPIKey("analyst-key"))
+	require.NoError(test, os.WriteFile(apiKeyFile, []byte(apiKeys), 0o600))
+
+	httpServer := &httpserver.BasicHTTPServer{
+		APIKeyFile:           apiKeyFile,
+		EnableSenzingChatAPI: true,
+		EnableSwaggerUI:      true,
+		EntityEngine:         &healthEntityEngine{err: nil},
+		OpenAPISpecification: senzingchatservice.OpenAPISpecificationJSON,
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	// Probes and the OpenAPI specification need no credentials.
+
+	response := getWhenReady(test, baseURL+"/livez")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	response = getWhenReady(test, baseURL+"/swagger/swagger_spec")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	var specification struct {
+		Security []map[string][]string `json:"security"`
+	}
+
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&specification))
+	require.Equal(test, []map[string][]string{{authentication.SecuritySchemeAPIKey: {}}}, specification.Security)
+
+	// Senzing data needs credentials.
+
+	for _, path := range []string{"/chat/entity_details?entity_id=1", "/site/search"} {
+		response = getWhenReady(test, baseURL+path)
+		require.Equal(test, http.StatusUnauthorized, response.StatusCode, path)
+
+		request := newRequest(test, baseURL+path)
+		request.Header.Set(authentication.HeaderAPIKey, "guessed-key")
+		require.Equal(test, http.StatusUnauthorized, doRequest(test, request), path)
+
+		request = newRequest(test, baseURL+path)
+		request.Header.Set(authentication.HeaderAPIKey, "analyst-key")
+		require.Equal(test, http.StatusOK, doRequest(test, request), path)
+	}
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_badAPIKeyFile(test *testing.T) {
+	test.Parallel()
+	httpServer := &httpserver.BasicHTTPServer{
+		APIKeyFile:   "/no/such/api-keys.json",
+		AvoidServing: true,
+	}
+	require.Error(test, httpServer.Serve(test.Context()))
+}
+
+func TestHTTPServerImpl_Serve_authorization(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	directory := test.TempDir()
+	apiKeyFile := filepath.Join(directory, "api-keys.json")
+	apiKeys := fmt.Sprintf(`{%q: "analyst"}`, authentication.HashAPIKey("analyst-key"))
+	require.NoError(test, os.WriteFile(apiKeyFile, []byte(apiKeys), 0o600))
+
+	policyFile := filepath.Join(directory, "policy.json")
+	policy := `{
+		"principals": {"api-key:analyst": ["searcher"]},
+		"roles": {"searcher": {"data_sources": ["*"], "operations": ["search"]}}
+	}`
+	require.NoError(test, os.WriteFile(policyFile, []byte(policy), 0o600))
+
+	httpServer := &httpserver.BasicHTTPServer{
+		APIKeyFile:              apiKeyFile,
+		AuthorizationPolicyFile: policyFile,
+		EnableSenzingChatAPI:    true,
+		EntityEngine:            &healthEntityEngine{err: nil},
+		ServerAddress:           "127.0.0.1",
+		ServerPort:              port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	getWhenReady(test, baseURL+"/livez")
+
+	for path, statusCode := range map[string]int{
+		"/chat/entity_details?entity_id=1": http.StatusForbidden,
+		"/chat/entity_how?entity_id=1":     http.StatusForbidden,
+		"/site/entity/1":                   http.StatusForbidden,
+	} {
+		request := newRequest(test, baseURL+path)
+		request.Header.Set(authentication.HeaderAPIKey, "analyst-key")
+		require.Equal(test, statusCode, doRequest(test, request), path)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/chat/entity_search",
+		strings.NewReader(`{"NAME_FULL": "Robert Smith"}`))
+	require.NoError(test, err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(authentication.HeaderAPIKey, "analyst-key")
+	require.Equal(test, http.StatusOK, doRequest(test, request))
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_authorizationWithoutAuthentication(test *testing.T) {
+	test.Parallel()
+	policyFile := filepath.Join(test.TempDir(), "policy.json")
+	require.NoError(test, os.WriteFile(policyFile, []byte(`{"principals": {}, "roles": {}}`), 0o600))
+
+	httpServer := &httpserver.BasicHTTPServer{
+		AuthorizationPolicyFile: policyFile,
+		AvoidServing:            true,
+	}
+	require.Error(test, httpServer.Serve(test.Context()))
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func doRequest(test *testing.T, request *http.Request) int {
+	test.Helper()
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(test, err)
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	require.NoError(test, response.Body.Close())
+
+	return response.StatusCode
+}
@@ -0,0 +1,216 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// HealthCheck is the result of one readiness check.
+type HealthCheck struct {
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Status     string `json:"status"`
+}
+
+// HealthReport is the body of the /livez and /readyz responses.
+type HealthReport struct {
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+	Status string                 `json:"status"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of HealthCheck.Status and HealthReport.Status.
+const (
+	HealthStatusFail    = "fail"
+	HealthStatusOK      = "ok"
+	HealthStatusSkipped = "skipped"
+)
+
+const (
+	healthCheckGrpc    = "grpc"
+	healthCheckSenzing = "senzing"
+	healthCheckTimeout = 5 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Run every readiness check concurrently.  Unconfigured dependencies are skipped, not failed, unless the chat API
+// needs them.
+func (httpServer *BasicHTTPServer) getHealthReport(ctx context.Context) HealthReport {
+	checks := map[string]func(context.Context) (string, error){}
+
+	if httpServer.baseEntityEngine != nil {
+		checks[healthCheckSenzing] = httpServer.checkEntityEngine
+	}
+
+	if len(httpServer.GrpcTarget) > 0 {
+		checks[healthCheckGrpc] = httpServer.checkGrpcTarget
+	}
+
+	for name, healthChecker := range httpServer.HealthCheckers {
+		checks[name] = func(ctx context.Context) (string, error) {
+			return "", healthChecker.CheckHealth(ctx) //nolint:wrapcheck
+		}
+	}
+
+	result := HealthReport{
+		Checks: map[string]HealthCheck{},
+		Status: HealthStatusOK,
+	}
+
+	if httpServer.baseEntityEngine == nil {
+		result.Checks[healthCheckSenzing] = HealthCheck{
+			Detail:     "Senzing engine is not configured",
+			DurationMs: 0,
+			Status:     HealthStatusSkipped,
+		}
+
+		// The chat API cannot answer without it.
+		if httpServer.EnableAll || httpServer.EnableSenzingChatAPI {
+			result.Checks[healthCheckSenzing] = HealthCheck{
+				Detail:     "Senzing engine is not configured, but the chat API is enabled",
+				DurationMs: 0,
+				Status:     HealthStatusFail,
+			}
+			result.Status = HealthStatusFail
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+	)
+
+	for name, check := range checks {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			healthCheck := runHealthCheck(ctx, check)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			result.Checks[name] = healthCheck
+			if healthCheck.Status == HealthStatusFail {
+				result.Status = HealthStatusFail
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	select {
+	case <-httpServer.shuttingDown:
+		result.Status = HealthStatusFail
+	default:
+	}
+
+	return result
+}
+
+// The engine answering calls, not its recording or metering wrappers, reports on its own factory and database.
+// An engine that cannot report is not known to be ready.
+func (httpServer *BasicHTTPServer) checkEntityEngine(ctx context.Context) (string, error) {
+	engine, ok := httpServer.baseEntityEngine.(HealthChecker)
+	if !ok {
+		return "", wraperror.Errorf(errForPackage, "the Senzing engine, a %T, does not implement CheckHealth",
+			httpServer.baseEntityEngine)
+	}
+
+	err := engine.CheckHealth(ctx)
+
+	return "factory initialized and database reachable", wraperror.Errorf(err, "CheckHealth")
+}
+
+// Ask the gRPC server for its status using the standard gRPC health checking protocol.
+func (httpServer *BasicHTTPServer) checkGrpcTarget(ctx context.Context) (string, error) {
+	grpcConnection, err := grpc.NewClient(httpServer.GrpcTarget, httpServer.grpcDialOptions...)
+	if err != nil {
+		return "", wraperror.Errorf(err, "grpc.NewClient: %s", httpServer.GrpcTarget)
+	}
+	defer grpcConnection.Close()
+
+	response, err := grpc_health_v1.NewHealthClient(grpcConnection).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return "", wraperror.Errorf(err, "Check: %s", httpServer.GrpcTarget)
+	}
+
+	if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return response.GetStatus().String(), wraperror.Errorf(errForPackage, "%s is %s",
+			httpServer.GrpcTarget, response.GetStatus())
+	}
+
+	return httpServer.GrpcTarget + " is " + response.GetStatus().String(), nil
+}
+
+// --- Http Funcs -------------------------------------------------------------
+
+// The process is alive if it can answer; liveness deliberately does not depend on Senzing.
+func (httpServer *BasicHTTPServer) livezFunc(writer http.ResponseWriter, request *http.Request) {
+	_ = request
+
+	writeHealthReport(writer, HealthReport{
+		Checks: nil,
+		Status: HealthStatusOK,
+	})
+}
+
+func (httpServer *BasicHTTPServer) readyzFunc(writer http.ResponseWriter, request *http.Request) {
+	writeHealthReport(writer, httpServer.getHealthReport(request.Context()))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func runHealthCheck(ctx context.Context, check func(context.Context) (string, error)) HealthCheck {
+	start := time.Now()
+	detail, err := check(ctx)
+
+	result := HealthCheck{
+		Detail:     detail,
+		DurationMs: time.Since(start).Milliseconds(),
+		Status:     HealthStatusOK,
+	}
+
+	if err != nil {
+		result.Detail = err.Error()
+		result.Status = HealthStatusFail
+	}
+
+	return result
+}
+
+func writeHealthReport(writer http.ResponseWriter, report HealthReport) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", "no-store")
+
+	if report.Status != HealthStatusOK {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+}
@@ -0,0 +1,193 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/stretchr/testify/require"
+)
+
+var errDatabase = errors.New("database unreachable")
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPServerImpl_Serve_health(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EntityEngine: &healthEntityEngine{err: errDatabase},
+		HealthCheckers: map[string]httpserver.HealthChecker{
+			"llm": &healthEntityEngine{err: nil},
+		},
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	response := getWhenReady(test, baseURL+"/livez")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	response = getWhenReady(test, baseURL+"/readyz")
+	require.Equal(test, http.StatusServiceUnavailable, response.StatusCode)
+
+	var report httpserver.HealthReport
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(test, httpserver.HealthStatusFail, report.Status)
+	require.Equal(test, httpserver.HealthStatusFail, report.Checks["senzing"].Status)
+	require.Contains(test, report.Checks["senzing"].Detail, errDatabase.Error())
+	require.Equal(test, httpserver.HealthStatusOK, report.Checks["llm"].Status)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_healthWithoutEngine(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	response := getWhenReady(test, fmt.Sprintf("http://127.0.0.1:%d/readyz", port))
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	var report httpserver.HealthReport
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(test, httpserver.HealthStatusSkipped, report.Checks["senzing"].Status)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_healthWithoutEngineForChat(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableSenzingChatAPI: true,
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	response := getWhenReady(test, fmt.Sprintf("http://127.0.0.1:%d/readyz", port))
+	require.Equal(test, http.StatusServiceUnavailable, response.StatusCode)
+
+	var report httpserver.HealthReport
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(test, httpserver.HealthStatusFail, report.Checks["senzing"].Status)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_healthWithNewEntityEngine(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableAll: true,
+		NewEntityEngine: func(ctx context.Context) (senzingchatservice.EntityEngine, error) {
+			_ = ctx
+
+			return &healthEntityEngine{err: nil}, nil
+		},
+		OpenAPISpecification: senzingchatservice.OpenAPISpecificationJSON,
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	response := getWhenReady(test, fmt.Sprintf("http://127.0.0.1:%d/readyz", port))
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	var report httpserver.HealthReport
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(test, httpserver.HealthStatusOK, report.Status)
+	require.Equal(test, httpserver.HealthStatusOK, report.Checks["senzing"].Status)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_healthWithoutCheckHealth(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EntityEngine:  &siteEntityEngine{},
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	response := getWhenReady(test, fmt.Sprintf("http://127.0.0.1:%d/readyz", port))
+	require.Equal(test, http.StatusServiceUnavailable, response.StatusCode)
+
+	var report httpserver.HealthReport
+	require.NoError(test, json.NewDecoder(response.Body).Decode(&report))
+	require.Equal(test, httpserver.HealthStatusFail, report.Checks["senzing"].Status)
+	require.Contains(test, report.Checks["senzing"].Detail, "CheckHealth")
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+// ----------------------------------------------------------------------------
+// Mock EntityEngine
+// ----------------------------------------------------------------------------
+
+type healthEntityEngine struct {
+	err error
+}
+
+func (engine *healthEntityEngine) CheckHealth(ctx context.Context) error {
+	_ = ctx
+
+	return engine.err
+}
+
+func (engine *healthEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return "{}", nil
+}
+
+func (engine *healthEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return "{}", nil
+}
+
+func (engine *healthEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	_ = ctx
+	_ = attributes
+
+	return "{}", nil
+}
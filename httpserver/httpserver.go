@@ -1,21 +1,33 @@
 package httpserver
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"io/fs"
+	"net"
 	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/flowchartsman/swaggerui"
 	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/serve-chat/authentication"
+	"github.com/senzing-garage/serve-chat/metrics"
+	"github.com/senzing-garage/serve-chat/promptregistry"
+	"github.com/senzing-garage/serve-chat/recording"
 	"github.com/senzing-garage/serve-chat/senzingchatapi"
 	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 )
 
@@ -25,41 +37,96 @@ import (
 
 // BasicHTTPServer is the default implementation of the HttpServer interface.
 type BasicHTTPServer struct {
-	AvoidServing          bool
-	ChatURLRoutePrefix    string // IMPROVE: Only works with "chat"
-	EnableAll             bool
-	EnableSenzingChatAPI  bool
-	EnableSwaggerUI       bool
-	GrpcDialOptions       []grpc.DialOption
-	GrpcTarget            string
-	LogLevelName          string
-	ObserverOrigin        string
-	Observers             []observer.Observer
-	OpenAPISpecification  []byte
-	ReadHeaderTimeout     time.Duration
-	Setting               string
-	SenzingInstanceName   string
-	SenzingVerboseLogging int64
-	ServerAddress         string
-	ServerOptions         []senzingchatapi.ServerOption
-	ServerPort            int
-	SwaggerURLRoutePrefix string // IMPROVE: Only works with "swagger"
+	APIKeyFile              string                         // JSON object mapping API key SHA-256 hashes to names
+	AuditAttributes         string                         // How search attributes are audited, e.g. "hash"
+	AuditFile               string                         // Hash-chained JSONL log of every Senzing call
+	AuditKeyFile            string                         // Secret that keys the AuditFile hash chain
+	Authenticators          []authentication.Authenticator // Additional ways to authenticate callers
+	AuthorizationPolicyFile string                         // JSON roles, and the principals granted them
+	AvoidServing            bool
+	ChatURLRoutePrefix      string // Path under which the chat API is served, e.g. "chat" or "/api/v2/chat"
+	ClientIdentityFile      string // JSON object mapping client certificate subjects to identity names
+	EnableAll               bool
+	EnableMetrics           bool // Serve Prometheus metrics at /metrics
+	EnableSenzingChatAPI    bool
+	EnableSwaggerUI         bool
+	EntityEngine            senzingchatservice.EntityEngine
+	GrpcDialOptions         []grpc.DialOption
+	GrpcTarget              string
+	HealthCheckers          map[string]HealthChecker // Additional /readyz checks, by name
+	JWKSFile                string                   // Keys that sign JWT bearer tokens
+	JWTAudience             string                   // When set, bearer tokens must be issued for it
+	JWTIssuer               string                   // When set, bearer tokens must be issued by it
+	LogLevelName            string
+	NewEntityEngine         EntityEngineFactory         // Creates the engine when EntityEngine is nil
+	Middleware              []senzingchatapi.Middleware // Chat API middleware, run after authentication
+	ObserverOrigin          string
+	Observers               []observer.Observer
+	OpenAPISpecification    []byte
+	PromptDir               string
+	ReadHeaderTimeout       time.Duration
+	RecordFile              string
+	ReplayFile              string
+	Setting                 string
+	SenzingInstanceName     string
+	SenzingVerboseLogging   int64
+	ServerAddress           string
+	ServerCACertificateFile string // Client CA bundle; when set, clients must present a certificate
+	ServerCertificateFile   string // When set, serve HTTPS
+	ServerKeyFile           string
+	ServerKeyPassphrase     string
+	ServerOptions           []senzingchatapi.ServerOption // Use Middleware, not WithMiddleware, to add middleware
+	ServerPort              int
+	ShutdownGracePeriod     time.Duration
+	SwaggerURLRoutePrefix   string               // Path under which Swagger UI is served, e.g. "swagger"
+	TLSMinimumVersion       string               // "1.2" or "1.3"
+	TLSReloadInterval       time.Duration        // How often to check for a renewed certificate; 1 minute if zero
+	TracerProvider          trace.TracerProvider // When set, spans are exported and trace context is propagated
+	authenticators          []authentication.Authenticator
+	baseEntityEngine        senzingchatservice.EntityEngine // The engine answering calls, before wrapping
+	clientIdentities        map[string]string
+	entityEngine            senzingchatservice.EntityEngine
+	grpcDialOptions         []grpc.DialOption
+	meterProvider           metric.MeterProvider
+	promptRegistry          *promptregistry.BasicPromptRegistry
+	shuttingDown            chan struct{} // Closed when shutdown starts, so /readyz fails while draining
 }
 
 type TemplateVariables struct {
 	BasicHTTPServer
 	ChatServerStatus string
 	ChatServerURL    string
+	Entity           *EntityPage
+	ErrorMessage     string
 	HTMLTitle        string
 	RequestHost      string
+	Search           *SearchPage
 	SwaggerStatus    string
 	SwaggerURL       string
 }
 
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	defaultChatURLRoutePrefix    = "chat"
+	defaultSwaggerURLRoutePrefix = "swagger"
+	defaultTLSMinimumVersion     = "1.2"
+	defaultTLSReloadInterval     = time.Minute
+	recordFilePerm               = 0o600
+)
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
 
+// First path segments of the routes served alongside the chat API and Swagger UI.
+var reservedRouteSegments = []string{"livez", "metrics", "readyz", "site"}
+
+// Characters allowed in a route prefix segment.  Others, like spaces and braces, mean something to http.ServeMux.
+var routeSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
 //go:embed static/*
 var static embed.FS
 
@@ -68,39 +135,119 @@ var static embed.FS
 // ----------------------------------------------------------------------------
 
 /*
-The Serve method simply prints the 'Something' value in the type-struct.
+The Serve method serves the enabled routes until ctx is cancelled, then shuts down gracefully:
+it stops accepting connections and lets in-flight requests finish within ShutdownGracePeriod,
+after which their contexts are cancelled.
 
 Input
-  - ctx: A context to control lifecycle.
+  - ctx: A context to control lifecycle.  Cancelling it starts the shutdown.
 
 Output
-  - Nothing is returned, except for an error.  However, something is printed.
-    See the example output.
+  - An error if the server could not be set up or started, or did not shut down within ShutdownGracePeriod.
 */
-
 func (httpServer *BasicHTTPServer) Serve(ctx context.Context) error {
 	rootMux := http.NewServeMux()
+	httpServer.shuttingDown = make(chan struct{})
 
 	var userMessages []string
 
+	err := httpServer.checkRoutePrefixes()
+	if err != nil {
+		return wraperror.Errorf(err, "checkRoutePrefixes")
+	}
+
+	// Create the Senzing engine, and apply recording and replay to it.
+
+	closeRecordFile, err := httpServer.setupEntityEngine(ctx)
+	defer httpServer.destroyEntityEngine(ctx)
+
+	if err != nil {
+		return wraperror.Errorf(err, "setupEntityEngine")
+	}
+
+	defer closeRecordFile()
+
+	// Trace calls to Senzing, in-process or over gRPC.
+
+	httpServer.setupTracing()
+
+	// Create metrics before the chat API, which records them.
+
+	shutdownMetrics, err := httpServer.addMetricsToMux(rootMux)
+	if err != nil {
+		return wraperror.Errorf(err, "addMetricsToMux")
+	}
+
+	defer shutdownMetrics()
+
+	// Load prompts, so a bad --prompt-dir is reported at startup.
+
+	httpServer.promptRegistry = &promptregistry.BasicPromptRegistry{
+		PromptDir: httpServer.PromptDir,
+	}
+
+	err = httpServer.promptRegistry.Load(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "Load prompts")
+	}
+
+	// Load credentials and the authorization policy, so a bad file is reported at startup.
+
+	err = httpServer.setupAuthentication(ctx)
+	if err != nil {
+		return wraperror.Errorf(err, "setupAuthentication")
+	}
+
+	err = httpServer.setupAuthorization()
+	if err != nil {
+		return wraperror.Errorf(err, "setupAuthorization")
+	}
+
+	// Audit every Senzing call, with what authorization refused or removed.
+
+	closeAuditFile, err := httpServer.setupAudit()
+	if err != nil {
+		return wraperror.Errorf(err, "setupAudit")
+	}
+
+	defer closeAuditFile()
+
 	// Add to root Mux.
 
-	userMessages = append(userMessages, httpServer.addChatToMux(ctx, rootMux)...)
-	userMessages = append(userMessages, httpServer.addSwagerToMux(ctx, rootMux)...)
+	chatMessages, err := httpServer.addChatToMux(ctx, rootMux)
+	if err != nil {
+		return wraperror.Errorf(err, "addChatToMux")
+	}
+
+	swaggerMessages, err := httpServer.addSwagerToMux(ctx, rootMux)
+	if err != nil {
+		return wraperror.Errorf(err, "addSwagerToMux")
+	}
+
+	userMessages = append(userMessages, chatMessages...)
+	userMessages = append(userMessages, swaggerMessages...)
+
+	// Add routes for probes.
 
-	// Add route to template pages.
+	rootMux.HandleFunc("GET /livez", httpServer.livezFunc)
+	rootMux.HandleFunc("GET /readyz", httpServer.readyzFunc)
 
-	rootMux.HandleFunc("/site/", httpServer.siteFunc)
+	// Add route to template pages.  They show Senzing data, so callers must be authenticated.
+
+	rootMux.Handle("/site/", httpServer.authenticationHandler(http.HandlerFunc(httpServer.siteFunc)))
+	rootMux.Handle("GET /site/entity/{id}", httpServer.authenticationHandler(http.HandlerFunc(httpServer.siteEntityFunc)))
+	rootMux.Handle("GET /site/search", httpServer.authenticationHandler(http.HandlerFunc(httpServer.siteSearchFunc)))
+	rootMux.Handle("POST /site/search", httpServer.authenticationHandler(http.HandlerFunc(httpServer.siteSearchFunc)))
 	userMessages = append(
 		userMessages,
-		fmt.Sprintf("Serving Console at          http://localhost:%d\n", httpServer.ServerPort),
+		fmt.Sprintf("Serving Console at          %s://localhost:%d\n", httpServer.scheme(), httpServer.ServerPort),
 	)
 
 	// Add route to static files.
 
 	rootDir, err := fs.Sub(static, "static/root")
 	if err != nil {
-		panic(err)
+		return wraperror.Errorf(err, "fs.Sub")
 	}
 
 	rootMux.Handle("/", http.StripPrefix("/", http.FileServer(http.FS(rootDir))))
@@ -111,20 +258,39 @@ func (httpServer *BasicHTTPServer) Serve(ctx context.Context) error {
 	userMessages = append(userMessages,
 		fmt.Sprintf("Starting server on interface:port '%s'...", listenOnAddress))
 
-	for userMessage := range userMessages {
+	for _, userMessage := range userMessages {
 		outputln(userMessage)
 	}
 
-	server := http.Server{
+	// Middleware, outermost first: recover from panics, continue the caller's trace, identify the client
+	// and the conversation.
+
+	var handler http.Handler = rootMux
+	handler = httpServer.conversationHandler(handler)
+	handler = httpServer.clientCertificateHandler(handler)
+	handler = httpServer.traceContextHandler(handler)
+	handler = recoverHandler(handler)
+
+	server := &http.Server{
 		ReadHeaderTimeout: httpServer.ReadHeaderTimeout,
 		Addr:              listenOnAddress,
-		Handler:           rootMux,
+		Handler:           handler,
 	}
 
-	if !httpServer.AvoidServing {
-		err = server.ListenAndServe()
+	if httpServer.isTLS() {
+		server.TLSConfig, err = httpServer.getTLSConfig()
+		if err != nil {
+			return wraperror.Errorf(err, "getTLSConfig")
+		}
 
-		return wraperror.Errorf(err, "ListenAndServe")
+		err = httpServer.loadClientIdentities()
+		if err != nil {
+			return wraperror.Errorf(err, "loadClientIdentities")
+		}
+	}
+
+	if !httpServer.AvoidServing {
+		return httpServer.listenAndServe(ctx, server)
 	}
 
 	return nil
@@ -137,43 +303,205 @@ func (httpServer *BasicHTTPServer) Serve(ctx context.Context) error {
 func (httpServer *BasicHTTPServer) addChatToMux(
 	ctx context.Context,
 	rootMux *http.ServeMux,
-) []string {
+) ([]string, error) {
 	var result []string
 
 	if httpServer.EnableAll || httpServer.EnableSenzingChatAPI {
-		senzingAPIMux := httpServer.getSenzingChatMux(ctx)
-		rootMux.Handle(fmt.Sprintf("/%s/", httpServer.ChatURLRoutePrefix), http.StripPrefix("/chat", senzingAPIMux))
+		senzingAPIMux, err := httpServer.getSenzingChatMux(ctx)
+		if err != nil {
+			return result, wraperror.Errorf(err, "getSenzingChatMux")
+		}
+
+		rootMux.Handle(httpServer.chatRoutePrefix()+"/", senzingAPIMux)
 		result = append(result,
 			fmt.Sprintf(
-				"Serving Senzing Chat API at http://localhost:%d/%s",
+				"Serving Senzing Chat API at %s://localhost:%d%s",
+				httpServer.scheme(),
 				httpServer.ServerPort,
-				httpServer.ChatURLRoutePrefix))
+				httpServer.chatRoutePrefix()))
 	}
 
-	return result
+	return result, nil
+}
+
+// Serve the Prometheus exporter at /metrics, give its MeterProvider to the chat API, and time Senzing calls.
+func (httpServer *BasicHTTPServer) addMetricsToMux(rootMux *http.ServeMux) (func(), error) {
+	if !httpServer.EnableAll && !httpServer.EnableMetrics {
+		return func() {}, nil
+	}
+
+	meterProvider, metricsHandler, err := metrics.NewPrometheusMeterProvider()
+	if err != nil {
+		return func() {}, wraperror.Errorf(err, "NewPrometheusMeterProvider")
+	}
+
+	shutdownMetrics := func() { _ = meterProvider.Shutdown(context.Background()) }
+
+	instruments, err := metrics.NewInstruments(meterProvider)
+	if err != nil {
+		shutdownMetrics()
+
+		return func() {}, wraperror.Errorf(err, "NewInstruments")
+	}
+
+	if httpServer.entityEngine != nil {
+		httpServer.entityEngine = &metrics.MeteredEntityEngine{
+			EntityEngine: httpServer.entityEngine,
+			Instruments:  instruments,
+		}
+	}
+
+	httpServer.meterProvider = meterProvider
+
+	rootMux.Handle("GET /metrics", metricsHandler)
+
+	return shutdownMetrics, nil
 }
 
 func (httpServer *BasicHTTPServer) addSwagerToMux(
 	ctx context.Context,
 	rootMux *http.ServeMux,
-) []string {
+) ([]string, error) {
 	var result []string
 
 	if httpServer.EnableAll || httpServer.EnableSwaggerUI {
-		swaggerUIMux := httpServer.getSwaggerUIMux(ctx)
+		swaggerUIMux, err := httpServer.getSwaggerUIMux(ctx)
+		if err != nil {
+			return result, wraperror.Errorf(err, "getSwaggerUIMux")
+		}
+
 		rootMux.Handle(
-			fmt.Sprintf("/%s/", httpServer.SwaggerURLRoutePrefix),
-			http.StripPrefix("/swagger", swaggerUIMux),
+			httpServer.swaggerRoutePrefix()+"/",
+			http.StripPrefix(httpServer.swaggerRoutePrefix(), swaggerUIMux),
 		)
 
 		result = append(result,
 			fmt.Sprintf(
-				"Serving SwaggerUI at        http://localhost:%d/%s",
+				"Serving SwaggerUI at        %s://localhost:%d%s",
+				httpServer.scheme(),
 				httpServer.ServerPort,
-				httpServer.SwaggerURLRoutePrefix))
+				httpServer.swaggerRoutePrefix()))
 	}
 
-	return result
+	return result, nil
+}
+
+// Reject route prefixes that http.ServeMux would not take literally, or that would take over, or clash with,
+// another route of the root mux.
+func (httpServer *BasicHTTPServer) checkRoutePrefixes() error {
+	enabled := map[string]bool{
+		"chat API":   httpServer.EnableAll || httpServer.EnableSenzingChatAPI,
+		"Swagger UI": httpServer.EnableAll || httpServer.EnableSwaggerUI,
+	}
+	prefixes := map[string]string{
+		"chat API":   httpServer.chatRoutePrefix(),
+		"Swagger UI": httpServer.swaggerRoutePrefix(),
+	}
+
+	for name, prefix := range prefixes {
+		if !enabled[name] {
+			continue
+		}
+
+		segments := strings.Split(strings.TrimPrefix(prefix, "/"), "/")
+		for _, segment := range segments {
+			if !routeSegmentPattern.MatchString(segment) || segment == "." || segment == ".." {
+				return wraperror.Errorf(errForPackage,
+					"the %s route prefix %q has segment %q; use only letters, digits and \"-._~\"",
+					name, prefix, segment)
+			}
+		}
+
+		if slices.Contains(reservedRouteSegments, segments[0]) {
+			return wraperror.Errorf(errForPackage, "the %s route prefix %q starts with a reserved segment: one of %s",
+				name, prefix, strings.Join(reservedRouteSegments, ", "))
+		}
+	}
+
+	if enabled["chat API"] && enabled["Swagger UI"] && prefixes["chat API"] == prefixes["Swagger UI"] {
+		return wraperror.Errorf(errForPackage, "the chat API and Swagger UI cannot share the route prefix %q",
+			prefixes["chat API"])
+	}
+
+	return nil
+}
+
+func (httpServer *BasicHTTPServer) chatRoutePrefix() string {
+	return routePrefix(httpServer.ChatURLRoutePrefix, defaultChatURLRoutePrefix)
+}
+
+func (httpServer *BasicHTTPServer) swaggerRoutePrefix() string {
+	return routePrefix(httpServer.SwaggerURLRoutePrefix, defaultSwaggerURLRoutePrefix)
+}
+
+func (httpServer *BasicHTTPServer) getPromptRegistry() promptregistry.PromptRegistry {
+	if httpServer.promptRegistry == nil {
+		return &promptregistry.BasicPromptRegistry{
+			PromptDir: httpServer.PromptDir,
+		}
+	}
+
+	return httpServer.promptRegistry
+}
+
+// Determine the engine used by the service: the configured EntityEngine, one created by NewEntityEngine,
+// or the ReplayFile in their place, optionally recorded to RecordFile.
+func (httpServer *BasicHTTPServer) setupEntityEngine(ctx context.Context) (func(), error) {
+	closeRecordFile := func() {}
+	httpServer.baseEntityEngine = httpServer.EntityEngine
+
+	if len(httpServer.ReplayFile) == 0 && httpServer.EntityEngine == nil && httpServer.NewEntityEngine != nil {
+		engine, err := httpServer.NewEntityEngine(ctx)
+		if err != nil {
+			return closeRecordFile, wraperror.Errorf(err, "NewEntityEngine")
+		}
+
+		httpServer.baseEntityEngine = engine
+	}
+
+	if len(httpServer.ReplayFile) > 0 {
+		replayFile, err := os.Open(httpServer.ReplayFile)
+		if err != nil {
+			return closeRecordFile, wraperror.Errorf(err, "os.Open: %s", httpServer.ReplayFile)
+		}
+		defer replayFile.Close()
+
+		interactions, err := recording.ReadInteractions(replayFile)
+		if err != nil {
+			return closeRecordFile, wraperror.Errorf(err, "ReadInteractions: %s", httpServer.ReplayFile)
+		}
+
+		httpServer.baseEntityEngine = &recording.ReplayEntityEngine{
+			Interactions: interactions,
+		}
+	}
+
+	httpServer.entityEngine = httpServer.baseEntityEngine
+
+	if len(httpServer.RecordFile) > 0 && httpServer.entityEngine != nil {
+		recordFile, err := os.OpenFile(httpServer.RecordFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, recordFilePerm)
+		if err != nil {
+			return closeRecordFile, wraperror.Errorf(err, "os.OpenFile: %s", httpServer.RecordFile)
+		}
+
+		closeRecordFile = func() { _ = recordFile.Close() }
+		httpServer.entityEngine = &recording.RecordingEntityEngine{
+			EntityEngine: httpServer.entityEngine,
+			Writer:       recordFile,
+		}
+	}
+
+	return closeRecordFile, nil
+}
+
+// If the engine holds resources, such as a Senzing abstract factory, release them.
+func (httpServer *BasicHTTPServer) destroyEntityEngine(ctx context.Context) {
+	if engine, ok := httpServer.baseEntityEngine.(destroyer); ok {
+		err := engine.Destroy(context.WithoutCancel(ctx))
+		if err != nil {
+			outputln(fmt.Sprintf("Destroying Senzing engine failed: %v", err))
+		}
+	}
 }
 
 func (httpServer *BasicHTTPServer) getServerStatus(active bool) string {
@@ -202,35 +530,123 @@ func (httpServer *BasicHTTPServer) getServerURL(active bool, url string) string
 	return result
 }
 
-func (httpServer *BasicHTTPServer) openAPIFunc(ctx context.Context, openAPISpecification []byte) http.HandlerFunc {
-	_ = ctx
-	_ = openAPISpecification
+// Serve until the server fails or ctx is cancelled.  On cancellation, stop accepting connections and
+// give in-flight requests ShutdownGracePeriod to finish before cancelling their contexts.
+func (httpServer *BasicHTTPServer) listenAndServe(ctx context.Context, server *http.Server) error {
+	requestCtx, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
 
-	return func(writer http.ResponseWriter, request *http.Request) {
-		var bytesBuffer bytes.Buffer
+	server.BaseContext = func(net.Listener) context.Context { return requestCtx }
 
-		bufioWriter := bufio.NewWriter(&bytesBuffer)
+	serveErr := make(chan error, 1)
 
-		openAPISpecificationTemplate, err := template.New("OpenApiTemplate").
-			Parse(string(httpServer.OpenAPISpecification))
-		if err != nil {
-			panic(err)
+	go func() {
+		if server.TLSConfig != nil {
+			serveErr <- server.ListenAndServeTLS("", "")
+
+			return
 		}
 
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return wraperror.Errorf(err, "ListenAndServe")
+	case <-ctx.Done():
+	}
+
+	close(httpServer.shuttingDown)
+	outputln("Shutting down; waiting for in-flight requests...")
+
+	shutdownCtx := context.WithoutCancel(ctx)
+
+	if httpServer.ShutdownGracePeriod > 0 {
+		var cancelShutdown context.CancelFunc
+
+		shutdownCtx, cancelShutdown = context.WithTimeout(shutdownCtx, httpServer.ShutdownGracePeriod)
+		defer cancelShutdown()
+	}
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		cancelRequests()
+
+		err = errors.Join(err, server.Close())
+	}
+
+	if serverErr := <-serveErr; !errors.Is(serverErr, http.ErrServerClosed) {
+		err = errors.Join(err, serverErr)
+	}
+
+	return wraperror.Errorf(err, "Shutdown")
+}
+
+// The specification is parsed once, so a bad template is reported by Serve rather than per request.
+func (httpServer *BasicHTTPServer) openAPIFunc(ctx context.Context, openAPISpecification []byte) (
+	http.HandlerFunc,
+	error,
+) {
+	_ = ctx
+
+	openAPISpecificationTemplate, err := template.New("OpenApiTemplate").Parse(string(openAPISpecification))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Parse OpenAPI specification")
+	}
+
+	return func(writer http.ResponseWriter, request *http.Request) {
+		var bytesBuffer bytes.Buffer
+
 		templateVariables := TemplateVariables{
 			RequestHost: request.Host,
 		}
 
-		err = openAPISpecificationTemplate.Execute(bufioWriter, templateVariables)
-		if err != nil {
-			panic(err)
+		err := openAPISpecificationTemplate.Execute(&bytesBuffer, templateVariables)
+		if err == nil {
+			err = httpServer.setOpenAPIServers(&bytesBuffer)
 		}
 
-		_, err = writer.Write(bytesBuffer.Bytes())
 		if err != nil {
-			panic(err)
+			senzingchatservice.Log(request.Context(), senzingchatservice.MessageOpenAPIFailed, err)
+			senzingchatservice.WriteError(
+				writer,
+				http.StatusInternalServerError,
+				senzingchatservice.MessageOpenAPIFailed,
+			)
+
+			return
 		}
+
+		_, _ = writer.Write(bytesBuffer.Bytes())
+	}, nil
+}
+
+// Point the specification's "servers" at the chat API, so Swagger UI sends requests to the configured prefix.
+// The URL is relative, so it holds behind a reverse proxy that preserves the path.
+// When callers must authenticate, require the configured security schemes, so Swagger UI sends credentials.
+func (httpServer *BasicHTTPServer) setOpenAPIServers(specification *bytes.Buffer) error {
+	var document map[string]any
+
+	err := json.Unmarshal(specification.Bytes(), &document)
+	if err != nil {
+		return wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	document["servers"] = []map[string]string{{"url": httpServer.chatRoutePrefix()}}
+
+	if security := httpServer.openAPISecurity(); len(security) > 0 {
+		document["security"] = security
 	}
+
+	result, err := json.Marshal(document)
+	if err != nil {
+		return wraperror.Errorf(err, "json.Marshal")
+	}
+
+	specification.Reset()
+	_, _ = specification.Write(result)
+
+	return nil
 }
 
 func (httpServer *BasicHTTPServer) populateStaticTemplate(
@@ -239,36 +655,43 @@ func (httpServer *BasicHTTPServer) populateStaticTemplate(
 	filepath string,
 	templateVariables TemplateVariables,
 ) {
-	_ = request
+	var bytesBuffer bytes.Buffer
 
 	templateBytes, err := static.ReadFile(filepath)
-	if err != nil {
-		http.Error(responseWriter, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	if err == nil {
+		var templateParsed *template.Template
 
-		return
+		templateParsed, err = template.New("HtmlTemplate").Parse(string(templateBytes))
+		if err == nil {
+			err = templateParsed.Execute(&bytesBuffer, templateVariables)
+		}
 	}
 
-	templateParsed, err := template.New("HtmlTemplate").Parse(string(templateBytes))
 	if err != nil {
-		http.Error(responseWriter, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		senzingchatservice.Log(request.Context(), senzingchatservice.MessagePageFailed, filepath, err)
+		http.Error(
+			responseWriter,
+			fmt.Sprintf(
+				"%s (%s)",
+				http.StatusText(http.StatusInternalServerError),
+				senzingchatservice.MessageID(senzingchatservice.MessagePageFailed),
+			),
+			http.StatusInternalServerError,
+		)
 
 		return
 	}
 
-	err = templateParsed.Execute(responseWriter, templateVariables)
-	if err != nil {
-		http.Error(responseWriter, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-
-		return
-	}
+	_, _ = responseWriter.Write(bytesBuffer.Bytes())
 }
 
 // --- http.ServeMux ----------------------------------------------------------
 
-func (httpServer *BasicHTTPServer) getSenzingChatMux(ctx context.Context) *senzingchatapi.Server {
+func (httpServer *BasicHTTPServer) getSenzingChatMux(ctx context.Context) (*senzingchatapi.Server, error) {
 	_ = ctx
 	service := &senzingchatservice.BasicChatAPIService{
-		GrpcDialOptions:          httpServer.GrpcDialOptions,
+		EntityEngine:             httpServer.entityEngine,
+		GrpcDialOptions:          httpServer.grpcDialOptions,
 		GrpcTarget:               httpServer.GrpcTarget,
 		LogLevelName:             httpServer.LogLevelName,
 		ObserverOrigin:           httpServer.ObserverOrigin,
@@ -276,45 +699,69 @@ func (httpServer *BasicHTTPServer) getSenzingChatMux(ctx context.Context) *senzi
 		Settings:                 httpServer.Setting,
 		SenzingInstanceName:      httpServer.SenzingInstanceName,
 		SenzingVerboseLogging:    httpServer.SenzingVerboseLogging,
-		URLRoutePrefix:           httpServer.ChatURLRoutePrefix,
+		URLRoutePrefix:           httpServer.chatRoutePrefix(),
 		OpenAPISpecificationSpec: httpServer.OpenAPISpecification,
+		PromptRegistry:           httpServer.getPromptRegistry(),
+	}
+
+	// Options given in ServerOptions come after these, so they take precedence.
+
+	serverOptions := []senzingchatapi.ServerOption{
+		senzingchatapi.WithErrorHandler(service.ErrorHandler),
+		senzingchatapi.WithPathPrefix(httpServer.chatRoutePrefix()),
+	}
+
+	if httpServer.meterProvider != nil {
+		serverOptions = append(serverOptions, senzingchatapi.WithMeterProvider(httpServer.meterProvider))
+	}
+
+	if httpServer.TracerProvider != nil {
+		serverOptions = append(serverOptions, senzingchatapi.WithTracerProvider(httpServer.TracerProvider))
+	}
+
+	serverOptions = append(serverOptions, httpServer.ServerOptions...)
+
+	// Middleware is set after ServerOptions, so a WithMiddleware option cannot remove authentication.
+
+	var middlewares []senzingchatapi.Middleware
+
+	if len(httpServer.authenticators) > 0 {
+		middlewares = append(middlewares, httpServer.authenticationMiddleware)
 	}
 
-	srv, err := senzingchatapi.NewServer(service, httpServer.ServerOptions...)
+	middlewares = append(middlewares, httpServer.Middleware...)
+
+	if len(middlewares) > 0 {
+		serverOptions = append(serverOptions, senzingchatapi.WithMiddleware(middlewares...))
+	}
+
+	srv, err := senzingchatapi.NewServer(service, serverOptions...)
 	if err != nil {
-		panic(err)
+		return nil, wraperror.Errorf(err, "NewServer")
 	}
 
-	return srv
+	return srv, nil
 }
 
-func (httpServer *BasicHTTPServer) getSwaggerUIMux(ctx context.Context) *http.ServeMux {
+func (httpServer *BasicHTTPServer) getSwaggerUIMux(ctx context.Context) (*http.ServeMux, error) {
+	openAPIFunc, err := httpServer.openAPIFunc(ctx, httpServer.OpenAPISpecification)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "openAPIFunc")
+	}
+
 	swaggerMux := swaggerui.Handler([]byte{}) // OpenAPI specification handled by openApiFunc()
 	swaggerFunc := swaggerMux.ServeHTTP
 	submux := http.NewServeMux()
 	submux.HandleFunc("/", swaggerFunc)
-	submux.HandleFunc("/swagger_spec", httpServer.openAPIFunc(ctx, httpServer.OpenAPISpecification))
+	submux.HandleFunc("/swagger_spec", openAPIFunc)
 
-	return submux
+	return submux, nil
 }
 
 // --- Http Funcs -------------------------------------------------------------
 
 func (httpServer *BasicHTTPServer) siteFunc(writer http.ResponseWriter, request *http.Request) {
-	templateVariables := TemplateVariables{
-		BasicHTTPServer: *httpServer,
-		HTMLTitle:       "serve-chat",
-		ChatServerURL: httpServer.getServerURL(
-			httpServer.EnableSenzingChatAPI,
-			fmt.Sprintf("http://%s/chat", request.Host),
-		),
-		ChatServerStatus: httpServer.getServerStatus(httpServer.EnableSenzingChatAPI),
-		SwaggerURL: httpServer.getServerURL(
-			httpServer.EnableSwaggerUI,
-			fmt.Sprintf("http://%s/swagger", request.Host),
-		),
-		SwaggerStatus: httpServer.getServerStatus(httpServer.EnableSwaggerUI),
-	}
+	templateVariables := httpServer.getSiteTemplateVariables(request)
 
 	writer.Header().Set("Content-Type", "text/html")
 
@@ -322,6 +769,49 @@ func (httpServer *BasicHTTPServer) siteFunc(writer http.ResponseWriter, request
 	httpServer.populateStaticTemplate(writer, request, filePath, templateVariables)
 }
 
+// --- Middleware -------------------------------------------------------------
+
+// Turn a panic in a handler into a logged 500 response instead of a dropped connection.
+func recoverHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			if recovered == http.ErrAbortHandler { //nolint:errorlint,err113
+				panic(recovered)
+			}
+
+			senzingchatservice.Log(
+				request.Context(),
+				senzingchatservice.MessageRecoveredPanic,
+				request.Method,
+				request.URL.Path,
+				recovered,
+			)
+			senzingchatservice.WriteError(
+				writer,
+				http.StatusInternalServerError,
+				senzingchatservice.MessageRecoveredPanic,
+			)
+		}()
+
+		next.ServeHTTP(writer, request)
+	})
+}
+
+// Normalize a route prefix to a leading slash and no trailing slash: "api/v2/chat/" becomes "/api/v2/chat".
+func routePrefix(prefix string, defaultPrefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if len(prefix) == 0 {
+		prefix = defaultPrefix
+	}
+
+	return "/" + prefix
+}
+
 func outputln(message ...any) {
 	fmt.Println(message...) //nolint
 }
@@ -1,9 +1,23 @@
 package httpserver_test
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/senzing-garage/serve-chat/tracing"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // ----------------------------------------------------------------------------
@@ -15,3 +29,435 @@ func TestHTTPServerImpl_Serve(test *testing.T) {
 
 	_ = httpserver.BasicHTTPServer{}
 }
+
+func TestHTTPServerImpl_Serve_badReplayFile(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing: true,
+		ReplayFile:   "/no/such/replay-file.jsonl",
+	}
+	err := httpServer.Serve(ctx)
+	require.Error(test, err)
+}
+
+func TestHTTPServerImpl_Serve_badOpenAPISpecification(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing:          true,
+		EnableSwaggerUI:       true,
+		OpenAPISpecification:  []byte("{{"),
+		SwaggerURLRoutePrefix: "swagger",
+	}
+	err := httpServer.Serve(ctx)
+	require.Error(test, err)
+}
+
+func TestHTTPServerImpl_Serve_shutdown(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	httpServer := &httpserver.BasicHTTPServer{
+		ServerAddress:       "127.0.0.1",
+		ServerPort:          0,
+		ShutdownGracePeriod: time.Second,
+	}
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	err := httpServer.Serve(ctx)
+	require.NoError(test, err)
+}
+
+func TestHTTPServerImpl_Serve_shutdownDrains(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	engine := newSlowEntityEngine()
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableSenzingChatAPI: true,
+		EntityEngine:         engine,
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+		ShutdownGracePeriod:  10 * time.Second,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	getWhenReady(test, baseURL+"/livez")
+
+	request := newRequest(test, baseURL+"/chat/entity_details?entity_id=1")
+	statusCode := make(chan int, 1)
+
+	go func() {
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			statusCode <- 0
+
+			return
+		}
+
+		_ = response.Body.Close()
+		statusCode <- response.StatusCode
+	}()
+
+	// A request in flight when shutdown starts is allowed to finish.
+
+	<-engine.started
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	close(engine.release)
+
+	require.Equal(test, http.StatusOK, <-statusCode)
+	require.NoError(test, <-served)
+	require.False(test, engine.cancelled.Load())
+}
+
+func TestHTTPServerImpl_Serve_shutdownGracePeriodExpires(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	engine := newSlowEntityEngine()
+	gracePeriod := 200 * time.Millisecond
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableSenzingChatAPI: true,
+		EntityEngine:         engine,
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+		ShutdownGracePeriod:  gracePeriod,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	getWhenReady(test, baseURL+"/livez")
+
+	request := newRequest(test, baseURL+"/chat/entity_details?entity_id=1")
+
+	go func() {
+		response, err := http.DefaultClient.Do(request)
+		if err == nil {
+			_ = response.Body.Close()
+		}
+	}()
+
+	// A request still blocked when the grace period ends is cut off, and Serve returns.
+
+	<-engine.started
+
+	start := time.Now()
+
+	cancel()
+
+	select {
+	case err := <-served:
+		require.Error(test, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(test, "Serve did not return after the grace period")
+	}
+
+	require.GreaterOrEqual(test, time.Since(start), gracePeriod)
+	require.Eventually(test, engine.cancelled.Load, time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPServerImpl_Serve_newEntityEngine(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	engine := &destroyableEntityEngine{}
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableSenzingChatAPI: true,
+		NewEntityEngine: func(ctx context.Context) (senzingchatservice.EntityEngine, error) {
+			_ = ctx
+
+			return engine, nil
+		},
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	response := getWhenReady(test, fmt.Sprintf("http://127.0.0.1:%d/chat/entity_details?entity_id=1", port))
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	cancel()
+	require.NoError(test, <-served)
+	require.True(test, engine.destroyed.Load())
+}
+
+func TestHTTPServerImpl_Serve_newEntityEngineFailed(test *testing.T) {
+	test.Parallel()
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing: true,
+		NewEntityEngine: func(ctx context.Context) (senzingchatservice.EntityEngine, error) {
+			_ = ctx
+
+			return nil, errDatabase
+		},
+	}
+	err := httpServer.Serve(test.Context())
+	require.ErrorContains(test, err, errDatabase.Error())
+}
+
+func TestHTTPServerImpl_Serve_routePrefixes(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		ChatURLRoutePrefix:    "/api/v2/chat/",
+		EnableAll:             true,
+		OpenAPISpecification:  senzingchatservice.OpenAPISpecificationJSON,
+		ServerAddress:         "127.0.0.1",
+		ServerPort:            port,
+		SwaggerURLRoutePrefix: "api/v2/swagger",
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	// Without an engine the chat API answers "not implemented", which shows the route was found.
+
+	response := getWhenReady(test, baseURL+"/api/v2/chat/entity_details?entity_id=1")
+	require.Equal(test, http.StatusNotImplemented, response.StatusCode)
+
+	response = getWhenReady(test, baseURL+"/api/v2/swagger/swagger_spec")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	var specification struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+	}
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(test, err)
+	require.NoError(test, json.Unmarshal(body, &specification))
+	require.Len(test, specification.Servers, 1)
+	require.Equal(test, "/api/v2/chat", specification.Servers[0].URL)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_routePrefixClashes(test *testing.T) {
+	test.Parallel()
+
+	for name, httpServer := range map[string]*httpserver.BasicHTTPServer{
+		"site":    {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "site"},
+		"metrics": {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "/metrics/"},
+		"livez":   {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "livez"},
+		"readyz":  {EnableSwaggerUI: true, SwaggerURLRoutePrefix: "readyz/docs"},
+		"swagger": {EnableAll: true, ChatURLRoutePrefix: "api", SwaggerURLRoutePrefix: "/api"},
+		"space":   {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "api v2"},
+		"brace":   {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "a{"},
+		"wild":    {EnableSenzingChatAPI: true, ChatURLRoutePrefix: "api/{v}"},
+		"empty":   {EnableSwaggerUI: true, SwaggerURLRoutePrefix: "api//docs"},
+		"dots":    {EnableSwaggerUI: true, SwaggerURLRoutePrefix: "api/../docs"},
+		"tab":     {EnableSwaggerUI: true, SwaggerURLRoutePrefix: "api\tdocs"},
+	} {
+		httpServer.AvoidServing = true
+		httpServer.OpenAPISpecification = senzingchatservice.OpenAPISpecificationJSON
+
+		require.ErrorContains(test, httpServer.Serve(test.Context()), "route prefix", name)
+	}
+
+	// A prefix is only checked when its route is served.
+
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing:          true,
+		ChatURLRoutePrefix:    "site",
+		EnableSwaggerUI:       true,
+		OpenAPISpecification:  senzingchatservice.OpenAPISpecificationJSON,
+		SwaggerURLRoutePrefix: "swagger",
+	}
+	require.NoError(test, httpServer.Serve(test.Context()))
+}
+
+func TestHTTPServerImpl_Serve_metrics(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableMetrics:        true,
+		EnableSenzingChatAPI: true,
+		EntityEngine:         &healthEntityEngine{err: nil},
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	response := getWhenReady(test, baseURL+"/chat/entity_details?entity_id=1")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	response = getWhenReady(test, baseURL+"/metrics")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(test, err)
+	require.Contains(test, string(body), `operation="GetEntityByEntityID"`)
+	require.Contains(test, string(body), "entity_details_entity_details_get")
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_tracing(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	recorder := tracetest.NewSpanRecorder()
+	httpServer := &httpserver.BasicHTTPServer{
+		EnableSenzingChatAPI: true,
+		EntityEngine:         &healthEntityEngine{err: nil},
+		ServerAddress:        "127.0.0.1",
+		ServerPort:           port,
+		TracerProvider:       sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/chat/entity_details?entity_id=1", port)
+	getWhenReady(test, url)
+
+	// The caller's trace is continued, and the Senzing call is a child of the request span.
+
+	request := newRequest(test, url)
+	request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(test, err)
+	require.NoError(test, response.Body.Close())
+	require.Equal(test, http.StatusOK, response.StatusCode)
+
+	var senzingSpan, requestSpan sdktrace.ReadOnlySpan
+
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			continue
+		}
+
+		if span.Name() == tracing.SpanSenzing+"GetEntityByEntityID" {
+			senzingSpan = span
+		} else {
+			requestSpan = span
+		}
+	}
+
+	require.NotNil(test, requestSpan)
+	require.Equal(test, "00f067aa0ba902b7", requestSpan.Parent().SpanID().String())
+	require.NotNil(test, senzingSpan)
+	require.Equal(test, requestSpan.SpanContext().SpanID(), senzingSpan.Parent().SpanID())
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func getFreePort(test *testing.T) int {
+	test.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(test, err)
+
+	tcpAddr, isTCPAddr := listener.Addr().(*net.TCPAddr)
+	require.True(test, isTCPAddr)
+	require.NoError(test, listener.Close())
+
+	return tcpAddr.Port
+}
+
+// GET url, retrying while the server starts.  An optional TLS configuration is used for https URLs.
+func getWhenReady(test *testing.T, url string, tlsConfigs ...*tls.Config) *http.Response {
+	test.Helper()
+
+	var (
+		err      error
+		response *http.Response
+	)
+
+	client := http.DefaultClient
+	if len(tlsConfigs) > 0 {
+		client = newHTTPClient(tlsConfigs[0])
+	}
+
+	for range 50 {
+		response, err = client.Do(newRequest(test, url))
+		if err == nil {
+			test.Cleanup(func() { _ = response.Body.Close() })
+
+			return response
+		}
+
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	require.NoError(test, err)
+
+	return response
+}
+
+// ----------------------------------------------------------------------------
+// Mock EntityEngine
+// ----------------------------------------------------------------------------
+
+// Answers GetEntityByEntityID once release is closed, or fails when the request is cancelled first.
+type slowEntityEngine struct {
+	healthEntityEngine
+
+	cancelled atomic.Bool
+	release   chan struct{}
+	started   chan struct{}
+}
+
+func newSlowEntityEngine() *slowEntityEngine {
+	return &slowEntityEngine{
+		healthEntityEngine: healthEntityEngine{err: nil},
+		cancelled:          atomic.Bool{},
+		release:            make(chan struct{}),
+		started:            make(chan struct{}, 1),
+	}
+}
+
+func (engine *slowEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = entityID
+
+	engine.started <- struct{}{}
+
+	select {
+	case <-engine.release:
+		return "{}", nil
+	case <-ctx.Done():
+		engine.cancelled.Store(true)
+
+		return "", ctx.Err() //nolint:wrapcheck
+	}
+}
+
+type destroyableEntityEngine struct {
+	healthEntityEngine
+
+	destroyed atomic.Bool
+}
+
+func (engine *destroyableEntityEngine) Destroy(ctx context.Context) error {
+	_ = ctx
+
+	engine.destroyed.Store(true)
+
+	return nil
+}
@@ -2,13 +2,41 @@ package httpserver
 
 import (
 	"context"
+	"errors"
+
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
 )
 
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
 
+// An EntityEngineFactory creates the Senzing engine when Serve starts, e.g. from a Senzing abstract factory.
+// An error stops Serve.
+type EntityEngineFactory func(ctx context.Context) (senzingchatservice.EntityEngine, error)
+
 // The HTTPServer interface...
 type HTTPServer interface {
 	Serve(ctx context.Context) error
 }
+
+// A HealthChecker reports whether a dependency, such as an LLM provider, is usable.
+// An EntityEngine that implements HealthChecker should verify its factory is initialized and its database reachable.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// An EntityEngine that holds resources, such as a Senzing abstract factory, implements destroyer.
+// Serve calls Destroy after the server has shut down.
+type destroyer interface {
+	Destroy(ctx context.Context) error
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errForPackage   = errors.New("httpserver")
+	errUnauthorized = errors.New("missing or invalid credentials")
+)
@@ -0,0 +1,488 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/narrative"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/senzing-garage/serve-chat/translator"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// EntityPage is the data shown on /site/entity/{id}.
+type EntityPage struct {
+	EntityID      int64
+	EntityName    string
+	Features      []EntityPageFeature
+	HowError      string
+	HowTree       []*EntityPageHowNode
+	Narrative     string
+	Records       []EntityPageDataSource
+	Relationships []EntityPageRelationship
+}
+
+// EntityPageDataSource lists the records of an entity from one data source.
+type EntityPageDataSource struct {
+	DataSource string
+	RecordIDs  []string
+}
+
+// EntityPageFeature lists the values of one feature type of an entity.
+type EntityPageFeature struct {
+	FeatureType string
+	Values      []string
+}
+
+// EntityPageHowNode is a virtual entity in the tree of how an entity was resolved.
+// Leaves are the starting virtual entities; each parent is the result of merging its children.
+type EntityPageHowNode struct {
+	Children        []*EntityPageHowNode
+	Explanation     string
+	Records         []string
+	Step            int
+	VirtualEntityID string
+	isChild         bool
+}
+
+// EntityPageRelationship is a related entity shown on an EntityPage.
+type EntityPageRelationship struct {
+	EntityID    int64
+	EntityName  string
+	Explanation string
+	MatchLevel  string
+}
+
+// SearchPage is the data shown on /site/search.  Searches are posted, so PII stays out of URLs,
+// and with them access logs, browser history and Referer headers.
+type SearchPage struct {
+	Attributes []SearchPageAttribute
+	Results    []SearchPageResult
+	Searched   bool
+}
+
+// SearchPageAttribute is one field of the search form.
+type SearchPageAttribute struct {
+	Name  string
+	Value string
+}
+
+// SearchPageResult is one entity found by a search.
+type SearchPageResult struct {
+	EntityID    int64
+	EntityName  string
+	Explanation string
+	MatchLevel  string
+}
+
+type entityDocument struct {
+	RelatedEntities []struct {
+		EntityID       int64  `json:"ENTITY_ID"`
+		EntityName     string `json:"ENTITY_NAME"`
+		MatchKey       string `json:"MATCH_KEY"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID   int64  `json:"ENTITY_ID"`
+		EntityName string `json:"ENTITY_NAME"`
+		Features   map[string][]struct {
+			FeatDesc string `json:"FEAT_DESC"`
+		} `json:"FEATURES"`
+		Records []senzingRecord `json:"RECORDS"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+type howDocument struct {
+	HowResults struct {
+		ResolutionSteps []struct {
+			MatchInfo struct {
+				MatchKey string `json:"MATCH_KEY"`
+			} `json:"MATCH_INFO"`
+			ResultVirtualEntityID string        `json:"RESULT_VIRTUAL_ENTITY_ID"`
+			Step                  int           `json:"STEP"`
+			VirtualEntity1        virtualEntity `json:"VIRTUAL_ENTITY_1"`
+			VirtualEntity2        virtualEntity `json:"VIRTUAL_ENTITY_2"`
+		} `json:"RESOLUTION_STEPS"`
+	} `json:"HOW_RESULTS"`
+}
+
+type searchDocument struct {
+	ResolvedEntities []struct {
+		Entity struct {
+			ResolvedEntity struct {
+				EntityID   int64  `json:"ENTITY_ID"`
+				EntityName string `json:"ENTITY_NAME"`
+			} `json:"RESOLVED_ENTITY"`
+		} `json:"ENTITY"`
+		MatchInfo struct {
+			MatchKey       string `json:"MATCH_KEY"`
+			MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+		} `json:"MATCH_INFO"`
+	} `json:"RESOLVED_ENTITIES"`
+}
+
+type senzingRecord struct {
+	DataSource string `json:"DATA_SOURCE"`
+	RecordID   string `json:"RECORD_ID"`
+}
+
+type virtualEntity struct {
+	MemberRecords []struct {
+		Records []senzingRecord `json:"RECORDS"`
+	} `json:"MEMBER_RECORDS"`
+	VirtualEntityID string `json:"VIRTUAL_ENTITY_ID"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Fields of the search form, in the order shown.  Names are those of the SearchAttributes schema.
+var searchPageAttributeNames = []string{
+	"NAME_FULL",
+	"NAME_FIRST",
+	"NAME_MIDDLE",
+	"NAME_LAST",
+	"NAME_ORG",
+	"DATE_OF_BIRTH",
+	"ADDR_FULL",
+	"ADDR_LINE1",
+	"ADDR_CITY",
+	"ADDR_STATE",
+	"ADDR_POSTAL_CODE",
+	"ADDR_COUNTRY",
+	"PHONE_NUMBER",
+	"EMAIL_ADDRESS",
+	"SSN_NUMBER",
+	"DRIVERS_LICENSE_NUMBER",
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (httpServer *BasicHTTPServer) getSiteTemplateVariables(request *http.Request) TemplateVariables {
+	return TemplateVariables{
+		BasicHTTPServer: *httpServer,
+		HTMLTitle:       "serve-chat",
+		ChatServerURL: httpServer.getServerURL(
+			httpServer.EnableSenzingChatAPI,
+			fmt.Sprintf("%s://%s%s", httpServer.scheme(), request.Host, httpServer.chatRoutePrefix()),
+		),
+		ChatServerStatus: httpServer.getServerStatus(httpServer.EnableSenzingChatAPI),
+		SwaggerURL: httpServer.getServerURL(
+			httpServer.EnableSwaggerUI,
+			fmt.Sprintf("%s://%s%s", httpServer.scheme(), request.Host, httpServer.swaggerRoutePrefix()),
+		),
+		SwaggerStatus: httpServer.getServerStatus(httpServer.EnableSwaggerUI),
+	}
+}
+
+func (httpServer *BasicHTTPServer) newEntityPage(
+	ctx context.Context,
+	request *http.Request,
+	entityID int64,
+) (*EntityPage, error) {
+	entityJSON, err := httpServer.entityEngine.GetEntityByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "GetEntityByEntityID: %d", entityID)
+	}
+
+	var document entityDocument
+
+	err = json.Unmarshal([]byte(entityJSON), &document)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	entityTranslator := &translator.BasicTranslator{}
+	result := &EntityPage{
+		EntityID:      document.ResolvedEntity.EntityID,
+		EntityName:    document.ResolvedEntity.EntityName,
+		Features:      entityPageFeatures(document),
+		HowError:      "",
+		HowTree:       []*EntityPageHowNode{},
+		Narrative:     "",
+		Records:       entityPageRecords(document.ResolvedEntity.Records),
+		Relationships: []EntityPageRelationship{},
+	}
+
+	narrativeBuilder := &narrative.BasicNarrativeBuilder{
+		PromptRegistry: httpServer.getPromptRegistry(),
+		Translator:     entityTranslator,
+	}
+
+	result.Narrative, err = narrativeBuilder.Build(ctx, entityJSON, narrative.DefaultLocale)
+	if err != nil {
+		senzingchatservice.Log(ctx, senzingchatservice.MessageNarrativeFailed, entityID, err)
+
+		result.Narrative = ""
+	}
+
+	for _, relatedEntity := range document.RelatedEntities {
+		result.Relationships = append(result.Relationships, EntityPageRelationship{
+			EntityID:    relatedEntity.EntityID,
+			EntityName:  relatedEntity.EntityName,
+			Explanation: entityTranslator.MatchKey(relatedEntity.MatchKey),
+			MatchLevel:  entityTranslator.MatchLevel(relatedEntity.MatchLevelCode),
+		})
+	}
+
+	howJSON, err := httpServer.entityEngine.HowEntityByEntityID(ctx, entityID)
+	if err != nil {
+		if errors.Is(err, senzingchatservice.ErrForbidden) {
+			senzingchatservice.Log(ctx, senzingchatservice.MessageForbidden, request.Method, request.URL.Path, err)
+		} else {
+			senzingchatservice.Log(ctx, senzingchatservice.MessageRequestFailed, request.Method, request.URL.Path, err)
+		}
+
+		result.HowError = "How the entity resolved is not available."
+
+		return result, nil
+	}
+
+	result.HowTree, err = entityPageHowTree(howJSON, entityTranslator)
+	if err != nil {
+		senzingchatservice.Log(ctx, senzingchatservice.MessageRequestFailed, request.Method, request.URL.Path, err)
+
+		result.HowError = "How the entity resolved could not be read."
+	}
+
+	return result, nil
+}
+
+func (httpServer *BasicHTTPServer) newSearchPage(ctx context.Context, request *http.Request) (*SearchPage, error) {
+	result := &SearchPage{
+		Attributes: make([]SearchPageAttribute, 0, len(searchPageAttributeNames)),
+		Results:    []SearchPageResult{},
+		Searched:   false,
+	}
+	attributes := map[string]string{}
+
+	for _, name := range searchPageAttributeNames {
+		value := strings.TrimSpace(request.PostFormValue(name))
+		result.Attributes = append(result.Attributes, SearchPageAttribute{Name: name, Value: value})
+
+		if len(value) > 0 {
+			attributes[name] = value
+		}
+	}
+
+	if len(attributes) == 0 || httpServer.entityEngine == nil {
+		return result, nil
+	}
+
+	attributesJSON, err := json.Marshal(attributes)
+	if err != nil {
+		return result, wraperror.Errorf(err, "json.Marshal")
+	}
+
+	searchJSON, err := httpServer.entityEngine.SearchByAttributes(ctx, string(attributesJSON))
+	if err != nil {
+		return result, wraperror.Errorf(err, "SearchByAttributes")
+	}
+
+	var document searchDocument
+
+	err = json.Unmarshal([]byte(searchJSON), &document)
+	if err != nil {
+		return result, wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	entityTranslator := &translator.BasicTranslator{}
+	result.Searched = true
+
+	for _, resolvedEntity := range document.ResolvedEntities {
+		result.Results = append(result.Results, SearchPageResult{
+			EntityID:    resolvedEntity.Entity.ResolvedEntity.EntityID,
+			EntityName:  resolvedEntity.Entity.ResolvedEntity.EntityName,
+			Explanation: entityTranslator.MatchKey(resolvedEntity.MatchInfo.MatchKey),
+			MatchLevel:  entityTranslator.MatchLevel(resolvedEntity.MatchInfo.MatchLevelCode),
+		})
+	}
+
+	return result, nil
+}
+
+// --- Http Funcs -------------------------------------------------------------
+
+func (httpServer *BasicHTTPServer) siteEntityFunc(writer http.ResponseWriter, request *http.Request) {
+	ctx := request.Context()
+	templateVariables := httpServer.getSiteTemplateVariables(request)
+
+	writer.Header().Set("Content-Type", "text/html")
+
+	entityID, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+
+	switch {
+	case err != nil:
+		writer.WriteHeader(http.StatusBadRequest)
+
+		templateVariables.ErrorMessage = fmt.Sprintf("%q is not an entity ID.", request.PathValue("id"))
+	case httpServer.entityEngine == nil:
+		writer.WriteHeader(http.StatusServiceUnavailable)
+
+		templateVariables.ErrorMessage = "The Senzing engine is not configured."
+	default:
+		templateVariables.Entity, err = httpServer.newEntityPage(ctx, request, entityID)
+		if errors.Is(err, senzingchatservice.ErrForbidden) {
+			senzingchatservice.Log(ctx, senzingchatservice.MessageForbidden, request.Method, request.URL.Path, err)
+			writer.WriteHeader(http.StatusForbidden)
+
+			templateVariables.ErrorMessage = fmt.Sprintf("You are not permitted to see entity %d.", entityID)
+		} else if errors.Is(err, senzingchatservice.ErrNotFound) {
+			senzingchatservice.Log(ctx, senzingchatservice.MessageNotFound, request.Method, request.URL.Path, err)
+			writer.WriteHeader(http.StatusNotFound)
+
+			templateVariables.ErrorMessage = fmt.Sprintf("Entity %d does not exist.", entityID)
+		} else if err != nil {
+			senzingchatservice.Log(ctx, senzingchatservice.MessageRequestFailed, request.Method, request.URL.Path, err)
+			writer.WriteHeader(http.StatusInternalServerError)
+
+			templateVariables.ErrorMessage = fmt.Sprintf(
+				"Entity %d could not be retrieved (%s).",
+				entityID,
+				senzingchatservice.MessageID(senzingchatservice.MessageRequestFailed),
+			)
+		}
+	}
+
+	httpServer.populateStaticTemplate(writer, request, "static/templates/site/entity.html", templateVariables)
+}
+
+func (httpServer *BasicHTTPServer) siteSearchFunc(writer http.ResponseWriter, request *http.Request) {
+	var err error
+
+	ctx := request.Context()
+	templateVariables := httpServer.getSiteTemplateVariables(request)
+
+	writer.Header().Set("Content-Type", "text/html")
+
+	if httpServer.entityEngine == nil {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+
+		templateVariables.ErrorMessage = "The Senzing engine is not configured."
+	}
+
+	templateVariables.Search, err = httpServer.newSearchPage(ctx, request)
+	if errors.Is(err, senzingchatservice.ErrForbidden) {
+		senzingchatservice.Log(ctx, senzingchatservice.MessageForbidden, request.Method, request.URL.Path, err)
+		writer.WriteHeader(http.StatusForbidden)
+
+		templateVariables.ErrorMessage = "You are not permitted to search."
+	} else if err != nil {
+		senzingchatservice.Log(ctx, senzingchatservice.MessageRequestFailed, request.Method, request.URL.Path, err)
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		templateVariables.ErrorMessage = fmt.Sprintf(
+			"The search could not be completed (%s).",
+			senzingchatservice.MessageID(senzingchatservice.MessageRequestFailed),
+		)
+	}
+
+	httpServer.populateStaticTemplate(writer, request, "static/templates/site/search.html", templateVariables)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func entityPageFeatures(document entityDocument) []EntityPageFeature {
+	result := make([]EntityPageFeature, 0, len(document.ResolvedEntity.Features))
+
+	for featureType, instances := range document.ResolvedEntity.Features {
+		feature := EntityPageFeature{
+			FeatureType: featureType,
+			Values:      make([]string, 0, len(instances)),
+		}
+		for _, instance := range instances {
+			feature.Values = append(feature.Values, instance.FeatDesc)
+		}
+
+		result = append(result, feature)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].FeatureType < result[j].FeatureType })
+
+	return result
+}
+
+// Build the tree from the resolution steps.  Each step merges two virtual entities into a result,
+// so a virtual entity that is a step's result becomes the parent of the step's two inputs.
+func entityPageHowTree(howJSON string, entityTranslator translator.Translator) ([]*EntityPageHowNode, error) {
+	var document howDocument
+
+	err := json.Unmarshal([]byte(howJSON), &document)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	nodes := map[string]*EntityPageHowNode{}
+	getNode := func(virtualEntity virtualEntity) *EntityPageHowNode {
+		node, ok := nodes[virtualEntity.VirtualEntityID]
+		if !ok {
+			node = &EntityPageHowNode{VirtualEntityID: virtualEntity.VirtualEntityID} //nolint:exhaustruct
+			nodes[virtualEntity.VirtualEntityID] = node
+		}
+
+		if len(node.Records) == 0 {
+			for _, memberRecord := range virtualEntity.MemberRecords {
+				for _, record := range memberRecord.Records {
+					node.Records = append(node.Records, record.DataSource+": "+record.RecordID)
+				}
+			}
+		}
+
+		return node
+	}
+
+	for _, step := range document.HowResults.ResolutionSteps {
+		resultNode := getNode(virtualEntity{VirtualEntityID: step.ResultVirtualEntityID}) //nolint:exhaustruct
+		resultNode.Children = []*EntityPageHowNode{getNode(step.VirtualEntity1), getNode(step.VirtualEntity2)}
+		resultNode.Explanation = entityTranslator.MatchKey(step.MatchInfo.MatchKey)
+		resultNode.Step = step.Step
+
+		for _, child := range resultNode.Children {
+			child.isChild = true
+		}
+	}
+
+	result := []*EntityPageHowNode{}
+
+	for _, node := range nodes {
+		if !node.isChild {
+			result = append(result, node)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].VirtualEntityID < result[j].VirtualEntityID })
+
+	return result, nil
+}
+
+func entityPageRecords(records []senzingRecord) []EntityPageDataSource {
+	byDataSource := map[string][]string{}
+	for _, record := range records {
+		byDataSource[record.DataSource] = append(byDataSource[record.DataSource], record.RecordID)
+	}
+
+	result := make([]EntityPageDataSource, 0, len(byDataSource))
+	for dataSource, recordIDs := range byDataSource {
+		sort.Strings(recordIDs)
+		result = append(result, EntityPageDataSource{DataSource: dataSource, RecordIDs: recordIDs})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].DataSource < result[j].DataSource })
+
+	return result
+}
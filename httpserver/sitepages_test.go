@@ -0,0 +1,173 @@
+package httpserver_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPServerImpl_Serve_siteSearch(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	engine := &siteEntityEngine{}
+	httpServer := &httpserver.BasicHTTPServer{
+		EntityEngine:  engine,
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	// Attributes in the query string are not searched for, so they are not encouraged into URLs.
+
+	response := getWhenReady(test, baseURL+"/site/search?NAME_FULL=Robert+Smith")
+	require.Equal(test, http.StatusOK, response.StatusCode)
+	require.Empty(test, engine.searches())
+
+	form := url.Values{"NAME_FULL": {"Robert Smith"}, "SSN_NUMBER": {"123-45-6789"}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/site/search",
+		strings.NewReader(form.Encode()))
+	require.NoError(test, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.Equal(test, http.StatusOK, doRequest(test, request))
+	require.Equal(test, []string{`{"NAME_FULL":"Robert Smith","SSN_NUMBER":"123-45-6789"}`}, engine.searches())
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_siteEntity(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EntityEngine:  &siteEntityEngine{},
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	for path, statusCode := range map[string]int{
+		"/site/entity/1":   http.StatusOK,
+		"/site/entity/404": http.StatusNotFound,
+		"/site/entity/500": http.StatusInternalServerError,
+		"/site/entity/one": http.StatusBadRequest,
+	} {
+		response := getWhenReady(test, baseURL+path)
+		require.Equal(test, statusCode, response.StatusCode, path)
+	}
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_siteEntityHowFails(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		EntityEngine:  &siteEntityEngine{},
+		ServerAddress: "127.0.0.1",
+		ServerPort:    port,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	for path, howError := range map[string]string{
+		"/site/entity/403": "How the entity resolved is not available.",
+		"/site/entity/422": "How the entity resolved could not be read.",
+		"/site/entity/503": "How the entity resolved is not available.",
+	} {
+		response := getWhenReady(test, baseURL+path)
+		require.Equal(test, http.StatusOK, response.StatusCode, path)
+
+		body, err := io.ReadAll(response.Body)
+		require.NoError(test, err)
+		require.Contains(test, string(body), howError, path)
+	}
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+// ----------------------------------------------------------------------------
+// Mock EntityEngine
+// ----------------------------------------------------------------------------
+
+type siteEntityEngine struct {
+	attributes []string
+	mutex      sync.Mutex
+}
+
+// Entity 404 does not exist; entity 500 cannot be retrieved.
+func (engine *siteEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	switch entityID {
+	case http.StatusNotFound:
+		return "", wraperror.Errorf(senzingchatservice.ErrNotFound, "entity %d", entityID)
+	case http.StatusInternalServerError:
+		return "", wraperror.Errorf(errDatabase, "entity %d", entityID)
+	default:
+		return "{}", nil
+	}
+}
+
+// How entity 403 is forbidden, entity 422 is unreadable and entity 503 cannot be retrieved.
+func (engine *siteEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	switch entityID {
+	case http.StatusForbidden:
+		return "", wraperror.Errorf(senzingchatservice.ErrForbidden, "entity %d", entityID)
+	case http.StatusUnprocessableEntity:
+		return "not JSON", nil
+	case http.StatusServiceUnavailable:
+		return "", wraperror.Errorf(errDatabase, "entity %d", entityID)
+	default:
+		return "{}", nil
+	}
+}
+
+func (engine *siteEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	_ = ctx
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.attributes = append(engine.attributes, attributes)
+
+	return `{"RESOLVED_ENTITIES": []}`, nil
+}
+
+func (engine *siteEntityEngine) searches() []string {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	return engine.attributes
+}
@@ -0,0 +1,236 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	helperstls "github.com/senzing-garage/go-helpers/tls"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/identity"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// certificateReloader serves the certificate in certFile and keyFile, reloading it when either file changes,
+// so a renewed certificate takes effect without a restart.  The files are checked at most once per interval.
+type certificateReloader struct {
+	certFile    string
+	certificate *tls.Certificate
+	checkedAt   time.Time
+	failing     bool // The last check failed; logged once, until a check succeeds
+	interval    time.Duration
+	keyFile     string
+	loadErr     error // Why the files, unchanged since modTime, cannot be loaded
+	modTime     time.Time
+	mutex       sync.Mutex
+	passphrase  string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (httpServer *BasicHTTPServer) isTLS() bool {
+	return len(httpServer.ServerCertificateFile) > 0
+}
+
+func (httpServer *BasicHTTPServer) scheme() string {
+	if httpServer.isTLS() {
+		return "https"
+	}
+
+	return "http"
+}
+
+// Build the TLS configuration.  A client CA bundle turns on mutual TLS.
+func (httpServer *BasicHTTPServer) getTLSConfig() (*tls.Config, error) {
+	minVersion := httpServer.TLSMinimumVersion
+	if len(minVersion) == 0 {
+		minVersion = defaultTLSMinimumVersion
+	}
+
+	version, ok := tlsVersions[minVersion]
+	if !ok {
+		return nil, wraperror.Errorf(errForPackage, "unsupported TLS minimum version: %s", minVersion)
+	}
+
+	interval := httpServer.TLSReloadInterval
+	if interval <= 0 {
+		interval = defaultTLSReloadInterval
+	}
+
+	reloader := &certificateReloader{ //nolint:exhaustruct
+		certFile:   httpServer.ServerCertificateFile,
+		checkedAt:  time.Now(),
+		interval:   interval,
+		keyFile:    httpServer.ServerKeyFile,
+		passphrase: httpServer.ServerKeyPassphrase,
+	}
+
+	err := reloader.reload()
+	if err != nil {
+		return nil, wraperror.Errorf(err, "reload")
+	}
+
+	result := &tls.Config{ //nolint:exhaustruct
+		GetCertificate: reloader.getCertificate,
+		MinVersion:     version,
+	}
+
+	if len(httpServer.ServerCACertificateFile) > 0 {
+		caCertificates, err := os.ReadFile(httpServer.ServerCACertificateFile)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "os.ReadFile: %s", httpServer.ServerCACertificateFile)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCertificates) {
+			return nil, wraperror.Errorf(errForPackage, "no certificates in %s", httpServer.ServerCACertificateFile)
+		}
+
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+		result.ClientCAs = clientCAs
+	}
+
+	return result, nil
+}
+
+// Load the optional JSON object mapping client certificate subjects to identity names.
+func (httpServer *BasicHTTPServer) loadClientIdentities() error {
+	if len(httpServer.ClientIdentityFile) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(httpServer.ClientIdentityFile)
+	if err != nil {
+		return wraperror.Errorf(err, "os.ReadFile: %s", httpServer.ClientIdentityFile)
+	}
+
+	err = json.Unmarshal(content, &httpServer.clientIdentities)
+
+	return wraperror.Errorf(err, "json.Unmarshal: %s", httpServer.ClientIdentityFile)
+}
+
+// Attach the identity of a verified client certificate to the request context.
+// The subject, e.g. "CN=analyst,O=Example", is looked up in ClientIdentityFile; otherwise the common name is used.
+func (httpServer *BasicHTTPServer) clientCertificateHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.TLS != nil && len(request.TLS.VerifiedChains) > 0 {
+			subject := request.TLS.VerifiedChains[0][0].Subject
+
+			name, ok := httpServer.clientIdentities[subject.String()]
+			if !ok {
+				name = subject.CommonName
+			}
+
+			ctx := identity.NewContext(request.Context(), &identity.Identity{
+				Method: identity.MethodClientCertificate,
+				Name:   name,
+			})
+			request = request.WithContext(ctx)
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
+
+func (reloader *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	reloader.mutex.Lock()
+	due := time.Since(reloader.checkedAt) >= reloader.interval
+
+	if due {
+		reloader.checkedAt = time.Now()
+	}
+	reloader.mutex.Unlock()
+
+	if due {
+		reloader.logReload(reloader.reload())
+	}
+
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	return reloader.certificate, nil
+}
+
+// Log when reloading starts to fail and when it recovers, rather than on every check.
+func (reloader *certificateReloader) logReload(err error) {
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	switch {
+	case err != nil && !reloader.failing:
+		// Keep serving the previous certificate; a renewal may be half-written.
+		outputln("Reloading TLS certificate failed; serving the previous certificate:", err)
+	case err == nil && reloader.failing:
+		outputln("Reloading TLS certificate succeeded")
+	}
+
+	reloader.failing = err != nil
+}
+
+func (reloader *certificateReloader) reload() error {
+	modTime, err := reloader.latestModTime()
+	if err != nil {
+		return wraperror.Errorf(err, "latestModTime")
+	}
+
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+
+	if reloader.certificate != nil && !modTime.After(reloader.modTime) {
+		return reloader.loadErr
+	}
+
+	certificate, err := helperstls.LoadX509KeyPair(reloader.certFile, reloader.keyFile, reloader.passphrase)
+	if err != nil {
+		err = wraperror.Errorf(err, "LoadX509KeyPair: %s", reloader.certFile)
+
+		// Retry when the files change again, not on every check.
+		if reloader.certificate != nil {
+			reloader.loadErr = err
+			reloader.modTime = modTime
+		}
+
+		return err
+	}
+
+	reloader.certificate = &certificate
+	reloader.loadErr = nil
+	reloader.modTime = modTime
+
+	return nil
+}
+
+func (reloader *certificateReloader) latestModTime() (time.Time, error) {
+	var result time.Time
+
+	for _, file := range []string{reloader.certFile, reloader.keyFile} {
+		fileInfo, err := os.Stat(file)
+		if err != nil {
+			return result, wraperror.Errorf(err, "os.Stat: %s", file)
+		}
+
+		if fileInfo.ModTime().After(result) {
+			result = fileInfo.ModTime()
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,250 @@
+package httpserver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/serve-chat/httpserver"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	tls         tls.Certificate
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestHTTPServerImpl_Serve_mutualTLS(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificate(test, "test-ca", 1, nil)
+	server := newTestCertificate(test, "127.0.0.1", 2, certificateAuthority)
+	client := newTestCertificate(test, "analyst", 3, certificateAuthority)
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		ServerAddress:           "127.0.0.1",
+		ServerCACertificateFile: writePEM(test, directory, "ca.pem", certificateAuthority.certificate.Raw, nil),
+		ServerCertificateFile:   writePEM(test, directory, "server.pem", server.certificate.Raw, nil),
+		ServerKeyFile:           writePEM(test, directory, "server-key.pem", nil, server.key),
+		ServerPort:              port,
+		TLSMinimumVersion:       "1.3",
+		TLSReloadInterval:       time.Nanosecond, // Check on every handshake.
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	url := fmt.Sprintf("https://127.0.0.1:%d/", port)
+	roots := x509.NewCertPool()
+	roots.AddCert(certificateAuthority.certificate)
+
+	response := getWhenReady(test, url, &tls.Config{ //nolint:exhaustruct
+		Certificates: []tls.Certificate{client.tls},
+		MinVersion:   tls.VersionTLS13,
+		RootCAs:      roots,
+	})
+	require.Equal(test, http.StatusOK, response.StatusCode)
+	require.Equal(test, big.NewInt(2), response.TLS.PeerCertificates[0].SerialNumber)
+
+	// Without a client certificate, the handshake fails.
+
+	anonymousClient := newHTTPClient(&tls.Config{MinVersion: tls.VersionTLS13, RootCAs: roots}) //nolint:exhaustruct
+
+	_, err := anonymousClient.Do(newRequest(test, url)) //nolint:bodyclose
+	require.Error(test, err)
+
+	// A renewed server certificate is picked up without a restart.
+
+	renewed := newTestCertificate(test, "127.0.0.1", 4, certificateAuthority)
+	writePEM(test, directory, "server.pem", renewed.certificate.Raw, nil)
+	writePEM(test, directory, "server-key.pem", nil, renewed.key)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(test, os.Chtimes(filepath.Join(directory, "server.pem"), later, later))
+
+	response = getWhenReady(test, url, &tls.Config{ //nolint:exhaustruct
+		Certificates: []tls.Certificate{client.tls},
+		MinVersion:   tls.VersionTLS13,
+		RootCAs:      roots,
+	})
+	require.Equal(test, big.NewInt(4), response.TLS.PeerCertificates[0].SerialNumber)
+
+	// While the files are missing, the previous certificate is served.
+
+	require.NoError(test, os.Remove(filepath.Join(directory, "server.pem")))
+
+	response = getWhenReady(test, url, &tls.Config{ //nolint:exhaustruct
+		Certificates: []tls.Certificate{client.tls},
+		MinVersion:   tls.VersionTLS13,
+		RootCAs:      roots,
+	})
+	require.Equal(test, big.NewInt(4), response.TLS.PeerCertificates[0].SerialNumber)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_tlsReloadInterval(test *testing.T) {
+	test.Parallel()
+	ctx, cancel := context.WithCancel(test.Context())
+	directory := test.TempDir()
+	certificateAuthority := newTestCertificate(test, "test-ca", 1, nil)
+	server := newTestCertificate(test, "127.0.0.1", 2, certificateAuthority)
+	port := getFreePort(test)
+	httpServer := &httpserver.BasicHTTPServer{
+		ServerAddress:         "127.0.0.1",
+		ServerCertificateFile: writePEM(test, directory, "server.pem", server.certificate.Raw, nil),
+		ServerKeyFile:         writePEM(test, directory, "server-key.pem", nil, server.key),
+		ServerPort:            port,
+		TLSReloadInterval:     time.Hour,
+	}
+	served := make(chan error, 1)
+
+	go func() { served <- httpServer.Serve(ctx) }()
+
+	url := fmt.Sprintf("https://127.0.0.1:%d/", port)
+	roots := x509.NewCertPool()
+	roots.AddCert(certificateAuthority.certificate)
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots} //nolint:exhaustruct
+
+	response := getWhenReady(test, url, tlsConfig)
+	require.Equal(test, big.NewInt(2), response.TLS.PeerCertificates[0].SerialNumber)
+
+	// A renewal is not looked for until the interval has passed.
+
+	renewed := newTestCertificate(test, "127.0.0.1", 3, certificateAuthority)
+	writePEM(test, directory, "server.pem", renewed.certificate.Raw, nil)
+	writePEM(test, directory, "server-key.pem", nil, renewed.key)
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(test, os.Chtimes(filepath.Join(directory, "server.pem"), later, later))
+
+	response = getWhenReady(test, url, tlsConfig)
+	require.Equal(test, big.NewInt(2), response.TLS.PeerCertificates[0].SerialNumber)
+
+	cancel()
+	require.NoError(test, <-served)
+}
+
+func TestHTTPServerImpl_Serve_badTLSMinimumVersion(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	directory := test.TempDir()
+	server := newTestCertificate(test, "127.0.0.1", 1, nil)
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing:          true,
+		ServerCertificateFile: writePEM(test, directory, "server.pem", server.certificate.Raw, nil),
+		ServerKeyFile:         writePEM(test, directory, "server-key.pem", nil, server.key),
+		TLSMinimumVersion:     "1.0",
+	}
+	err := httpServer.Serve(ctx)
+	require.Error(test, err)
+}
+
+func TestHTTPServerImpl_Serve_missingCertificate(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	httpServer := &httpserver.BasicHTTPServer{
+		AvoidServing:          true,
+		ServerCertificateFile: "/no/such/server.pem",
+		ServerKeyFile:         "/no/such/server-key.pem",
+	}
+	err := httpServer.Serve(ctx)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+// Create a certificate signed by issuer, or self-signed CA certificate when issuer is nil.
+func newTestCertificate(test *testing.T, commonName string, serial int64, issuer *testCertificate) *testCertificate {
+	test.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(test, err)
+
+	template := &x509.Certificate{ //nolint:exhaustruct
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		NotAfter:     time.Now().Add(time.Hour),
+		NotBefore:    time.Now().Add(-time.Hour),
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName}, //nolint:exhaustruct
+	}
+
+	parent, parentKey := template, key
+
+	if issuer == nil {
+		template.BasicConstraintsValid = true
+		template.IsCA = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		parent, parentKey = issuer.certificate, issuer.key
+		template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1)}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(test, err)
+
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(test, err)
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		tls:         tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, //nolint:exhaustruct
+	}
+}
+
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{ //nolint:exhaustruct
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true}, //nolint:exhaustruct
+	}
+}
+
+func newRequest(test *testing.T, url string) *http.Request {
+	test.Helper()
+
+	request, err := http.NewRequestWithContext(test.Context(), http.MethodGet, url, nil)
+	require.NoError(test, err)
+
+	return request
+}
+
+func writePEM(test *testing.T, directory string, name string, der []byte, key *ecdsa.PrivateKey) string {
+	test.Helper()
+
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: der} //nolint:exhaustruct
+
+	if key != nil {
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(test, err)
+
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER} //nolint:exhaustruct
+	}
+
+	result := filepath.Join(directory, name)
+	require.NoError(test, os.WriteFile(result, pem.EncodeToMemory(block), 0o600))
+
+	return result
+}
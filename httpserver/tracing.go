@@ -0,0 +1,53 @@
+package httpserver
+
+import (
+	"net/http"
+
+	"github.com/senzing-garage/serve-chat/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// With a TracerProvider, put Senzing calls in child spans and send the trace context to the Senzing gRPC server.
+func (httpServer *BasicHTTPServer) setupTracing() {
+	httpServer.grpcDialOptions = httpServer.GrpcDialOptions
+
+	if httpServer.TracerProvider == nil {
+		return
+	}
+
+	httpServer.grpcDialOptions = append(
+		append([]grpc.DialOption{}, httpServer.GrpcDialOptions...),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithTracerProvider(httpServer.TracerProvider),
+			otelgrpc.WithPropagators(tracing.NewPropagator()),
+		)),
+	)
+
+	if httpServer.entityEngine != nil {
+		httpServer.entityEngine = &tracing.TracedEntityEngine{
+			EntityEngine:   httpServer.entityEngine,
+			TracerProvider: httpServer.TracerProvider,
+		}
+	}
+}
+
+// Continue the caller's trace: ogen starts its server span from the request context,
+// so the W3C traceparent header is extracted into it first.
+func (httpServer *BasicHTTPServer) traceContextHandler(next http.Handler) http.Handler {
+	if httpServer.TracerProvider == nil {
+		return next
+	}
+
+	propagator := tracing.NewPropagator()
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := propagator.Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+		next.ServeHTTP(writer, request.WithContext(ctx))
+	})
+}
@@ -0,0 +1,7 @@
+/*
+Package identity carries the authenticated caller of a request in its context.
+
+The HTTP server determines the identity, for example from a client certificate,
+and the service reads it for authorization and audit.
+*/
+package identity
@@ -0,0 +1,38 @@
+package identity
+
+import (
+	"context"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The FromContext function returns the Identity stored in a context.
+
+Input
+  - ctx: A context, usually of an HTTP request.
+
+Output
+  - The Identity and true, or nil and false if the caller is anonymous.
+*/
+func FromContext(ctx context.Context) (*Identity, bool) {
+	result, ok := ctx.Value(contextKey{}).(*Identity)
+
+	return result, ok && result != nil
+}
+
+/*
+The NewContext function returns a copy of a context that carries an Identity.
+
+Input
+  - ctx: The parent context.
+  - identity: The authenticated caller.
+
+Output
+  - A context from which FromContext returns identity.
+*/
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
@@ -0,0 +1,29 @@
+package identity_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/identity"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFromContext(test *testing.T) {
+	test.Parallel()
+	ctx := identity.NewContext(test.Context(), &identity.Identity{
+		Method: identity.MethodClientCertificate,
+		Name:   "analyst",
+	})
+	result, ok := identity.FromContext(ctx)
+	require.True(test, ok)
+	require.Equal(test, "analyst", result.Name)
+}
+
+func TestFromContext_anonymous(test *testing.T) {
+	test.Parallel()
+	_, ok := identity.FromContext(test.Context())
+	require.False(test, ok)
+}
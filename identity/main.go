@@ -0,0 +1,24 @@
+package identity
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	Method string // How the caller was authenticated, e.g. MethodClientCertificate.
+	Name   string // The caller, e.g. a user or service name.
+}
+
+type contextKey struct{}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Methods of authentication.
+const (
+	MethodAPIKey            = "api-key"
+	MethodBearerToken       = "bearer-token"
+	MethodClientCertificate = "client-certificate"
+)
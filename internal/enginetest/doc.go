@@ -0,0 +1,8 @@
+/*
+Package enginetest provides a fake Senzing engine for tests.
+
+The fake answers GetEntityByEntityID, HowEntityByEntityID and SearchByAttributes
+with fixed documents or errors, so packages that wrap a
+senzingchatservice.EntityEngine can be tested without Senzing.
+*/
+package enginetest
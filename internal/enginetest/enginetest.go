@@ -0,0 +1,68 @@
+package enginetest
+
+import "context"
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// EntityEngine is a senzingchatservice.EntityEngine that answers every call with the configured documents.
+// An empty document is answered as "{}".
+type EntityEngine struct {
+	EntityJSON string
+	HowJSON    string
+	SearchJSON string
+
+	// Err, when set, is returned by every method instead of a document.
+	Err error
+
+	// EntityErrors are returned by GetEntityByEntityID instead of EntityJSON, by entity ID.
+	EntityErrors map[int64]error
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID answers EntityJSON, or the error configured for entityID.
+func (engine *EntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	if err, ok := engine.EntityErrors[entityID]; ok {
+		return "", err
+	}
+
+	return engine.answer(engine.EntityJSON)
+}
+
+// HowEntityByEntityID answers HowJSON.
+func (engine *EntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return engine.answer(engine.HowJSON)
+}
+
+// SearchByAttributes answers SearchJSON.
+func (engine *EntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	_ = ctx
+	_ = attributes
+
+	return engine.answer(engine.SearchJSON)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *EntityEngine) answer(document string) (string, error) {
+	if engine.Err != nil {
+		return "", engine.Err
+	}
+
+	if document == "" {
+		return "{}", nil
+	}
+
+	return document, nil
+}
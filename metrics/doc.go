@@ -0,0 +1,7 @@
+/*
+Package metrics defines the OpenTelemetry instruments of serve-chat and exports them for Prometheus.
+
+The ogen generated server records its own "requests", "errors" and "duration" metrics
+when given the MeterProvider made by NewPrometheusMeterProvider().
+*/
+package metrics
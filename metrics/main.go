@@ -0,0 +1,17 @@
+package metrics
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Instrumentation scope of the instruments.
+const MeterName = "github.com/senzing-garage/serve-chat"
+
+// Name of the instrument recording the latency of Senzing calls.
+const SenzingDuration = "serve_chat.senzing.duration"
+
+// Attribute keys.
+const (
+	AttributeError     = "error"
+	AttributeOperation = "operation"
+)
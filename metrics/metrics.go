@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/recording"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Instruments are the custom metrics of serve-chat.
+type Instruments struct {
+	SenzingDuration metric.Float64Histogram
+}
+
+// MeteredEntityEngine passes calls to EntityEngine and records their latency in Instruments.SenzingDuration.
+type MeteredEntityEngine struct {
+	EntityEngine senzingchatservice.EntityEngine
+	Instruments  *Instruments
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID calls the wrapped EntityEngine and records the latency.
+func (engine *MeteredEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	start := time.Now()
+	result, err := engine.EntityEngine.GetEntityByEntityID(ctx, entityID)
+	engine.Instruments.RecordSenzingCall(ctx, recording.OperationGetEntityByEntityID, start, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// HowEntityByEntityID calls the wrapped EntityEngine and records the latency.
+func (engine *MeteredEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	start := time.Now()
+	result, err := engine.EntityEngine.HowEntityByEntityID(ctx, entityID)
+	engine.Instruments.RecordSenzingCall(ctx, recording.OperationHowEntityByEntityID, start, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// SearchByAttributes calls the wrapped EntityEngine and records the latency.
+func (engine *MeteredEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	start := time.Now()
+	result, err := engine.EntityEngine.SearchByAttributes(ctx, attributes)
+	engine.Instruments.RecordSenzingCall(ctx, recording.OperationSearchByAttributes, start, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The RecordSenzingCall method records the latency of a call to the Senzing engine.
+
+Input
+  - ctx: A context to control lifecycle.
+  - operation: The Senzing method called, e.g. recording.OperationSearchByAttributes.
+  - start: When the call started.
+  - err: The error returned by the call, if any.
+*/
+func (instruments *Instruments) RecordSenzingCall(ctx context.Context, operation string, start time.Time, err error) {
+	instruments.SenzingDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String(AttributeOperation, operation),
+		attribute.Bool(AttributeError, err != nil),
+	))
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewInstruments function creates the custom metrics of serve-chat.
+
+Input
+  - meterProvider: The source of the meter.
+
+Output
+  - The instruments.
+*/
+func NewInstruments(meterProvider metric.MeterProvider) (*Instruments, error) {
+	var (
+		err    error
+		result Instruments
+	)
+
+	meter := meterProvider.Meter(MeterName)
+
+	result.SenzingDuration, err = meter.Float64Histogram(SenzingDuration,
+		metric.WithDescription("Latency of calls to the Senzing engine, by operation."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Float64Histogram: %s", SenzingDuration)
+	}
+
+	return &result, nil
+}
+
+/*
+The NewPrometheusMeterProvider function creates a MeterProvider whose metrics are served in the
+Prometheus text format.  Each call uses its own registry, so servers in one process do not collide.
+
+Output
+  - The MeterProvider.  Call Shutdown() when done.
+  - An http.Handler for the /metrics endpoint.
+*/
+func NewPrometheusMeterProvider() (*sdkmetric.MeterProvider, http.Handler, error) {
+	registry := prometheus.NewRegistry()
+
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, nil, wraperror.Errorf(err, "prometheus.New")
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{}) //nolint:exhaustruct
+
+	return meterProvider, handler, nil
+}
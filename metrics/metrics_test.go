@@ -0,0 +1,54 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/internal/enginetest"
+	"github.com/senzing-garage/serve-chat/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestMeteredEntityEngine_SearchByAttributes(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	meterProvider, handler, err := metrics.NewPrometheusMeterProvider()
+	require.NoError(test, err)
+
+	defer func() { require.NoError(test, meterProvider.Shutdown(context.Background())) }()
+
+	instruments, err := metrics.NewInstruments(meterProvider)
+	require.NoError(test, err)
+
+	engine := &metrics.MeteredEntityEngine{
+		EntityEngine: &enginetest.EntityEngine{}, //nolint:exhaustruct
+		Instruments:  instruments,
+	}
+	result, err := engine.SearchByAttributes(ctx, `{"NAME_FULL": "Robert Smith"}`)
+	require.NoError(test, err)
+	require.Equal(test, "{}", result)
+
+	body := scrape(test, handler)
+	require.Contains(test, body, "serve_chat_senzing_duration_seconds_count")
+	require.Contains(test, body, `operation="SearchByAttributes"`)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions
+// ----------------------------------------------------------------------------
+
+func scrape(test *testing.T, handler http.Handler) string {
+	test.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequestWithContext(test.Context(), http.MethodGet, "/metrics", nil))
+	require.Equal(test, http.StatusOK, recorder.Code)
+
+	return recorder.Body.String()
+}
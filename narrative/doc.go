@@ -0,0 +1,8 @@
+/*
+Package narrative builds a deterministic, one-paragraph profile of an entity
+from the Senzing "get entity" JSON document, without using a language model.
+
+The wording comes from the "entity-narrative" template in the promptregistry package,
+so it can be changed with --prompt-dir like any other prompt.
+*/
+package narrative
@@ -0,0 +1,53 @@
+package narrative
+
+import (
+	"context"
+
+	"github.com/senzing-garage/serve-chat/promptregistry"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The NarrativeBuilder interface produces a plain-text profile of an entity.
+type NarrativeBuilder interface {
+	Build(ctx context.Context, entityJSON string, locale string) (string, error)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// DefaultLocale is used when no locale, or an unknown locale, is given.
+const DefaultLocale = "en-US"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// DateLayouts maps BCP 47 language tags, or just the language, to a time.Format layout.
+var DateLayouts = map[string]string{
+	"de":    "02.01.2006",
+	"en":    "2006-01-02",
+	"en-CA": "2006-01-02",
+	"en-GB": "02/01/2006",
+	"en-US": "01/02/2006",
+	"es":    "02/01/2006",
+	"fr":    "02/01/2006",
+	"it":    "02/01/2006",
+	"ja":    "2006/01/02",
+	"nl":    "02-01-2006",
+	"zh":    "2006-01-02",
+}
+
+// Used when BasicNarrativeBuilder.PromptRegistry is not set.
+var defaultPromptRegistry = &promptregistry.BasicPromptRegistry{}
+
+// Layouts in which Senzing reports dates of birth.
+var dateOfBirthLayouts = []string{
+	"2006-1-2",
+	"1/2/2006",
+	"2006/1/2",
+	"20060102",
+}
@@ -0,0 +1,249 @@
+package narrative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/promptregistry"
+	"github.com/senzing-garage/serve-chat/translator"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// BasicNarrativeBuilder is the default implementation of the NarrativeBuilder interface.
+type BasicNarrativeBuilder struct {
+	PromptRegistry promptregistry.PromptRegistry
+	Translator     translator.Translator
+}
+
+// Profile is the data rendered by the "entity-narrative" template.
+type Profile struct {
+	Addresses     []string
+	DataSources   []string
+	DatesOfBirth  []string
+	EntityID      int64
+	Name          string
+	OtherNames    []string
+	RecordCount   int
+	Relationships []Relationship
+}
+
+// Relationship describes a related entity in a Profile.
+type Relationship struct {
+	Description string
+	EntityID    int64
+	Name        string
+}
+
+type entityDocument struct {
+	RelatedEntities []struct {
+		EntityID       int64  `json:"ENTITY_ID"`
+		EntityName     string `json:"ENTITY_NAME"`
+		MatchKey       string `json:"MATCH_KEY"`
+		MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+	} `json:"RELATED_ENTITIES"`
+	ResolvedEntity struct {
+		EntityID      int64                        `json:"ENTITY_ID"`
+		EntityName    string                       `json:"ENTITY_NAME"`
+		Features      map[string][]featureInstance `json:"FEATURES"`
+		RecordSummary []struct {
+			DataSource  string `json:"DATA_SOURCE"`
+			RecordCount int    `json:"RECORD_COUNT"`
+		} `json:"RECORD_SUMMARY"`
+	} `json:"RESOLVED_ENTITY"`
+}
+
+type featureInstance struct {
+	FeatDesc string `json:"FEAT_DESC"`
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Build method renders the narrative of an entity.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityJSON: The JSON document returned by SzEngine.GetEntityByEntityID().
+  - locale: A BCP 47 language tag, like "en-US", used to format dates.
+
+Output
+  - A single paragraph describing the entity.
+*/
+func (builder *BasicNarrativeBuilder) Build(ctx context.Context, entityJSON string, locale string) (string, error) {
+	profile, err := builder.NewProfile(ctx, entityJSON, locale)
+	if err != nil {
+		return "", wraperror.Errorf(err, "NewProfile")
+	}
+
+	prompt, err := builder.getPromptRegistry().Get(ctx, promptregistry.EntityNarrative)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Get: %s", promptregistry.EntityNarrative)
+	}
+
+	result, err := prompt.Render(profile)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Render")
+	}
+
+	return strings.TrimSpace(result), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The NewProfile method extracts the facts used in the narrative from a Senzing entity document.
+
+Input
+  - ctx: A context to control lifecycle.
+  - entityJSON: The JSON document returned by SzEngine.GetEntityByEntityID().
+  - locale: A BCP 47 language tag, like "en-US", used to format dates.
+
+Output
+  - The facts about the entity.
+*/
+func (builder *BasicNarrativeBuilder) NewProfile(ctx context.Context, entityJSON string, locale string) (
+	*Profile,
+	error,
+) {
+	_ = ctx
+
+	var document entityDocument
+
+	err := json.Unmarshal([]byte(entityJSON), &document)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	entity := document.ResolvedEntity
+	result := &Profile{
+		Addresses:     featureDescriptions(entity.Features["ADDRESS"]),
+		DataSources:   []string{},
+		DatesOfBirth:  []string{},
+		EntityID:      entity.EntityID,
+		Name:          entity.EntityName,
+		OtherNames:    []string{},
+		RecordCount:   0,
+		Relationships: []Relationship{},
+	}
+
+	for _, name := range featureDescriptions(entity.Features["NAME"]) {
+		if name != entity.EntityName {
+			result.OtherNames = append(result.OtherNames, name)
+		}
+	}
+
+	for _, dateOfBirth := range featureDescriptions(entity.Features["DOB"]) {
+		result.DatesOfBirth = append(result.DatesOfBirth, FormatDate(dateOfBirth, locale))
+	}
+
+	for _, recordSummary := range entity.RecordSummary {
+		result.DataSources = append(result.DataSources, recordSummary.DataSource)
+		result.RecordCount += recordSummary.RecordCount
+	}
+
+	for _, relatedEntity := range document.RelatedEntities {
+		description := builder.getTranslator().MatchLevel(relatedEntity.MatchLevelCode)
+		if matchKey := builder.getTranslator().MatchKey(relatedEntity.MatchKey); len(matchKey) > 0 {
+			description = fmt.Sprintf("%s: %s", description, matchKey)
+		}
+
+		result.Relationships = append(result.Relationships, Relationship{
+			Description: description,
+			EntityID:    relatedEntity.EntityID,
+			Name:        relatedEntity.EntityName,
+		})
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The FormatDate function reformats a date for a locale.
+Dates that cannot be parsed are returned unchanged.
+
+Input
+  - date: A date as found in Senzing features, like "1985-03-12" or "3/12/1985".
+  - locale: A BCP 47 language tag, like "en-GB".
+
+Output
+  - The date formatted for the locale.
+*/
+func FormatDate(date string, locale string) string {
+	for _, layout := range dateOfBirthLayouts {
+		parsed, err := time.Parse(layout, date)
+		if err == nil {
+			return parsed.Format(dateLayout(locale))
+		}
+	}
+
+	return date
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (builder *BasicNarrativeBuilder) getPromptRegistry() promptregistry.PromptRegistry {
+	if builder.PromptRegistry == nil {
+		return defaultPromptRegistry
+	}
+
+	return builder.PromptRegistry
+}
+
+func (builder *BasicNarrativeBuilder) getTranslator() translator.Translator {
+	if builder.Translator == nil {
+		return &translator.BasicTranslator{}
+	}
+
+	return builder.Translator
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func dateLayout(locale string) string {
+	language, region, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+
+	language = strings.ToLower(language)
+	if len(region) > 0 {
+		locale = language + "-" + strings.ToUpper(region)
+	}
+
+	if layout, ok := DateLayouts[locale]; ok {
+		return layout
+	}
+
+	if layout, ok := DateLayouts[language]; ok {
+		return layout
+	}
+
+	return DateLayouts[DefaultLocale]
+}
+
+func featureDescriptions(features []featureInstance) []string {
+	result := make([]string, 0, len(features))
+	for _, feature := range features {
+		if len(feature.FeatDesc) > 0 {
+			result = append(result, feature.FeatDesc)
+		}
+	}
+
+	return result
+}
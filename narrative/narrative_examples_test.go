@@ -0,0 +1,16 @@
+package narrative_test
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/serve-chat/narrative"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleFormatDate() {
+	fmt.Println(narrative.FormatDate("1985-03-12", "en-GB"))
+	// Output: 12/03/1985
+}
@@ -0,0 +1,99 @@
+package narrative_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/narrative"
+	"github.com/stretchr/testify/require"
+)
+
+const entityJSON = `{
+	"RESOLVED_ENTITY": {
+		"ENTITY_ID": 1,
+		"ENTITY_NAME": "Robert Smith",
+		"FEATURES": {
+			"ADDRESS": [{"FEAT_DESC": "123 Main Street, Las Vegas, NV 89132"}],
+			"DOB": [{"FEAT_DESC": "1985-03-12"}],
+			"NAME": [{"FEAT_DESC": "Robert Smith"}, {"FEAT_DESC": "Bob Smith"}]
+		},
+		"RECORD_SUMMARY": [
+			{"DATA_SOURCE": "CUSTOMERS", "RECORD_COUNT": 2},
+			{"DATA_SOURCE": "WATCHLIST", "RECORD_COUNT": 1}
+		]
+	},
+	"RELATED_ENTITIES": [
+		{"ENTITY_ID": 7, "ENTITY_NAME": "Jane Smith", "MATCH_LEVEL_CODE": "POSSIBLY_RELATED", "MATCH_KEY": "+ADDRESS"}
+	]
+}`
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicNarrativeBuilder_Build(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &narrative.BasicNarrativeBuilder{}
+	actual, err := testObject.Build(ctx, entityJSON, "en-US")
+	require.NoError(test, err)
+	require.Equal(test,
+		"Robert Smith, born 03/12/1985, also known as Bob Smith, appears in 2 data sources (CUSTOMERS and WATCHLIST);"+
+			" lives at 123 Main Street, Las Vegas, NV 89132;"+
+			" related to Jane Smith (entity 7, possibly related: same address).",
+		actual)
+}
+
+func TestBasicNarrativeBuilder_Build_minimal(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &narrative.BasicNarrativeBuilder{}
+	actual, err := testObject.Build(ctx, `{"RESOLVED_ENTITY": {"ENTITY_ID": 2, "ENTITY_NAME": "ACME Corp"}}`, "")
+	require.NoError(test, err)
+	require.Equal(test, "ACME Corp.", actual)
+}
+
+func TestBasicNarrativeBuilder_Build_badJSON(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &narrative.BasicNarrativeBuilder{}
+	_, err := testObject.Build(ctx, "}{", "en-US")
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestBasicNarrativeBuilder_NewProfile(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &narrative.BasicNarrativeBuilder{}
+	profile, err := testObject.NewProfile(ctx, entityJSON, "de-DE")
+	require.NoError(test, err)
+	require.Equal(test, int64(1), profile.EntityID)
+	require.Equal(test, 3, profile.RecordCount)
+	require.Equal(test, []string{"12.03.1985"}, profile.DatesOfBirth)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestFormatDate(test *testing.T) {
+	test.Parallel()
+	testCases := []struct {
+		date     string
+		locale   string
+		expected string
+	}{
+		{date: "1985-03-12", locale: "en-US", expected: "03/12/1985"},
+		{date: "1985-03-12", locale: "en_gb", expected: "12/03/1985"},
+		{date: "3/12/1985", locale: "ja-JP", expected: "1985/03/12"},
+		{date: "1985-03-12", locale: "xx", expected: "03/12/1985"},
+		{date: "sometime in 1985", locale: "en-US", expected: "sometime in 1985"},
+	}
+
+	for _, testCase := range testCases {
+		require.Equal(test, testCase.expected, narrative.FormatDate(testCase.date, testCase.locale), testCase)
+	}
+}
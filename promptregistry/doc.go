@@ -0,0 +1,8 @@
+/*
+Package promptregistry loads the versioned prompt templates used by serve-chat.
+
+Each prompt is a Go text/template preceded by a front-matter block that names
+and versions the prompt.  A default set of prompts is embedded in the binary.
+Prompts found in an optional directory override embedded prompts having the same name.
+*/
+package promptregistry
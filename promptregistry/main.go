@@ -0,0 +1,45 @@
+package promptregistry
+
+import (
+	"context"
+	"embed"
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The PromptRegistry interface is used to look up prompt templates by name.
+type PromptRegistry interface {
+	Get(ctx context.Context, name string) (*Prompt, error)
+	List(ctx context.Context) ([]*Prompt, error)
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Names of the prompts in the embedded default set.
+const (
+	EntityDetailsAnswer = "entity-details-answer"
+	EntityHowAnswer     = "entity-how-answer"
+	EntityNarrative     = "entity-narrative"
+	EntitySearchAnswer  = "entity-search-answer"
+	System              = "system"
+)
+
+// Values for Prompt.Source when the prompt did not come from a file in PromptDir.
+const SourceEmbedded = "embedded"
+
+// File extension of prompt template files.
+const FileExtension = ".tmpl"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("promptregistry")
+
+//go:embed prompts/*.tmpl
+var embeddedPrompts embed.FS
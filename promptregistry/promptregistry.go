@@ -0,0 +1,307 @@
+package promptregistry
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// AnswerData is the data rendered by the *-answer prompts.
+type AnswerData struct {
+	Explanation string
+	Question    string
+	Result      string
+}
+
+// BasicPromptRegistry is the default implementation of the PromptRegistry interface.
+type BasicPromptRegistry struct {
+	PromptDir string
+	loadErr   error
+	loadOnce  sync.Once
+	prompts   map[string]*Prompt
+}
+
+// Prompt is a parsed prompt template and its front-matter.
+type Prompt struct {
+	Description string   `yaml:"description"`
+	ModelHints  []string `yaml:"models"`
+	Name        string   `yaml:"name"`
+	Source      string   `yaml:"-"`
+	Version     string   `yaml:"version"`
+	template    *template.Template
+}
+
+// Reference identifies the exact prompt used, so it can be stored alongside the output it produced.
+type Reference struct {
+	Name    string `json:"name"`
+	Source  string `json:"source"`
+	Version string `json:"version"`
+}
+
+// SystemData is the data rendered by the system prompt.
+type SystemData struct {
+	DataSources []string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const frontMatterDelimiter = "---"
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var templateFuncs = template.FuncMap{
+	"join":   strings.Join,
+	"list":   joinEnglish,
+	"plural": plural,
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Get method returns the prompt having the given name.
+
+Input
+  - ctx: A context to control lifecycle.
+  - name: The name of the prompt as given in its front-matter.
+
+Output
+  - The prompt.
+*/
+func (registry *BasicPromptRegistry) Get(ctx context.Context, name string) (*Prompt, error) {
+	err := registry.Load(ctx)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Load")
+	}
+
+	result, ok := registry.prompts[name]
+	if !ok {
+		return nil, wraperror.Errorf(errForPackage, "unknown prompt: %s", name)
+	}
+
+	return result, nil
+}
+
+/*
+The List method returns all known prompts, sorted by name.
+
+Input
+  - ctx: A context to control lifecycle.
+
+Output
+  - All prompts.
+*/
+func (registry *BasicPromptRegistry) List(ctx context.Context) ([]*Prompt, error) {
+	err := registry.Load(ctx)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "Load")
+	}
+
+	result := make([]*Prompt, 0, len(registry.prompts))
+	for _, prompt := range registry.prompts {
+		result = append(result, prompt)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Load method reads the embedded prompts and then the prompts in PromptDir.
+Loading happens once; subsequent calls return the result of the first call.
+
+Input
+  - ctx: A context to control lifecycle.
+*/
+func (registry *BasicPromptRegistry) Load(ctx context.Context) error {
+	_ = ctx
+
+	registry.loadOnce.Do(func() {
+		registry.prompts = map[string]*Prompt{}
+
+		registry.loadErr = registry.loadFS(embeddedPrompts, "prompts", SourceEmbedded)
+		if registry.loadErr != nil {
+			return
+		}
+
+		if len(registry.PromptDir) > 0 {
+			registry.loadErr = registry.loadFS(os.DirFS(registry.PromptDir), ".", registry.PromptDir)
+		}
+	})
+
+	return registry.loadErr
+}
+
+/*
+The Reference method returns the identity of the prompt.
+
+Output
+  - The name, version, and source of the prompt.
+*/
+func (prompt *Prompt) Reference() Reference {
+	return Reference{
+		Name:    prompt.Name,
+		Source:  prompt.Source,
+		Version: prompt.Version,
+	}
+}
+
+/*
+The Render method executes the prompt template.
+
+Input
+  - data: The value passed to text/template's Execute().
+
+Output
+  - The rendered prompt.
+*/
+func (prompt *Prompt) Render(data any) (string, error) {
+	var result bytes.Buffer
+
+	err := prompt.template.Execute(&result, data)
+	if err != nil {
+		return "", wraperror.Errorf(err, "Execute prompt %s version %s", prompt.Name, prompt.Version)
+	}
+
+	return result.String(), nil
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Parse function parses a prompt file consisting of front-matter and a template.
+
+Input
+  - source: Where the prompt came from.  Used in error messages and Prompt.Source.
+  - content: The bytes of the prompt file.
+
+Output
+  - The parsed prompt.
+*/
+func Parse(source string, content []byte) (*Prompt, error) {
+	frontMatter, body, err := splitFrontMatter(content)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "splitFrontMatter: %s", source)
+	}
+
+	result := &Prompt{ //nolint:exhaustruct
+		Source: source,
+	}
+
+	err = yaml.Unmarshal(frontMatter, result)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "yaml.Unmarshal front-matter: %s", source)
+	}
+
+	if len(result.Name) == 0 || len(result.Version) == 0 {
+		return nil, wraperror.Errorf(errForPackage, "front-matter requires name and version: %s", source)
+	}
+
+	result.template, err = template.New(result.Name).
+		Funcs(templateFuncs).
+		Option("missingkey=error").
+		Parse(string(body))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "template.Parse: %s", source)
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (registry *BasicPromptRegistry) loadFS(fileSystem fs.FS, dir string, source string) error {
+	paths, err := fs.Glob(fileSystem, filepath.ToSlash(filepath.Join(dir, "*"+FileExtension)))
+	if err != nil {
+		return wraperror.Errorf(err, "fs.Glob: %s", source)
+	}
+
+	for _, path := range paths {
+		content, err := fs.ReadFile(fileSystem, path)
+		if err != nil {
+			return wraperror.Errorf(err, "fs.ReadFile: %s", path)
+		}
+
+		promptSource := source
+		if source != SourceEmbedded {
+			promptSource = filepath.Join(source, filepath.FromSlash(path))
+		}
+
+		prompt, err := Parse(promptSource, content)
+		if err != nil {
+			return wraperror.Errorf(err, "Parse")
+		}
+
+		registry.prompts[prompt.Name] = prompt
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Join phrases as in "a, b and c".
+func joinEnglish(phrases []string) string {
+	switch len(phrases) {
+	case 0:
+		return ""
+	case 1:
+		return phrases[0]
+	default:
+		return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+	}
+}
+
+func plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return plural
+}
+
+func splitFrontMatter(content []byte) ([]byte, []byte, error) {
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return nil, nil, wraperror.Errorf(errForPackage, "missing front-matter")
+	}
+
+	text = strings.TrimPrefix(text, frontMatterDelimiter+"\n")
+
+	frontMatter, body, found := strings.Cut(text, "\n"+frontMatterDelimiter+"\n")
+	if !found {
+		return nil, nil, wraperror.Errorf(errForPackage, "unterminated front-matter")
+	}
+
+	return []byte(frontMatter), []byte(body), nil
+}
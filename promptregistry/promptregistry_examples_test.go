@@ -0,0 +1,25 @@
+package promptregistry_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/senzing-garage/serve-chat/promptregistry"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleBasicPromptRegistry_Get() {
+	ctx := context.TODO()
+	registry := &promptregistry.BasicPromptRegistry{}
+
+	prompt, err := registry.Get(ctx, promptregistry.EntityHowAnswer)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(prompt.Reference().Name)
+	// Output: entity-how-answer
+}
@@ -0,0 +1,106 @@
+package promptregistry_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/promptregistry"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicPromptRegistry_Get(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	registry := &promptregistry.BasicPromptRegistry{}
+	prompt, err := registry.Get(ctx, promptregistry.System)
+	require.NoError(test, err)
+	require.Equal(test, promptregistry.System, prompt.Name)
+	require.Equal(test, promptregistry.SourceEmbedded, prompt.Source)
+	require.NotEmpty(test, prompt.Version)
+}
+
+func TestBasicPromptRegistry_Get_unknown(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	registry := &promptregistry.BasicPromptRegistry{}
+	_, err := registry.Get(ctx, "no-such-prompt")
+	require.Error(test, err)
+}
+
+func TestBasicPromptRegistry_Get_promptDir(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	promptDir := test.TempDir()
+	promptFile := filepath.Join(promptDir, "system.tmpl")
+	content := "---\nname: system\nversion: 2.0.0-test\n---\nHello {{.Name}}."
+	require.NoError(test, os.WriteFile(promptFile, []byte(content), 0o600))
+
+	registry := &promptregistry.BasicPromptRegistry{PromptDir: promptDir}
+	prompt, err := registry.Get(ctx, promptregistry.System)
+	require.NoError(test, err)
+	require.Equal(test, "2.0.0-test", prompt.Version)
+	require.Equal(test, promptFile, prompt.Reference().Source)
+
+	rendered, err := prompt.Render(map[string]string{"Name": "World"})
+	require.NoError(test, err)
+	require.Equal(test, "Hello World.", rendered)
+}
+
+func TestBasicPromptRegistry_List(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	registry := &promptregistry.BasicPromptRegistry{}
+	prompts, err := registry.List(ctx)
+	require.NoError(test, err)
+
+	for _, name := range []string{
+		promptregistry.EntityDetailsAnswer,
+		promptregistry.EntityHowAnswer,
+		promptregistry.EntitySearchAnswer,
+		promptregistry.System,
+	} {
+		found := false
+
+		for _, prompt := range prompts {
+			found = found || prompt.Name == name
+		}
+
+		require.True(test, found, name)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Test public methods
+// ----------------------------------------------------------------------------
+
+func TestPrompt_Render(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	registry := &promptregistry.BasicPromptRegistry{}
+	prompt, err := registry.Get(ctx, promptregistry.System)
+	require.NoError(test, err)
+	rendered, err := prompt.Render(promptregistry.SystemData{DataSources: []string{"CUSTOMERS", "WATCHLIST"}})
+	require.NoError(test, err)
+	require.Contains(test, rendered, "CUSTOMERS, WATCHLIST")
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestParse_missingFrontMatter(test *testing.T) {
+	test.Parallel()
+	_, err := promptregistry.Parse("test", []byte("No front-matter."))
+	require.Error(test, err)
+}
+
+func TestParse_missingVersion(test *testing.T) {
+	test.Parallel()
+	_, err := promptregistry.Parse("test", []byte("---\nname: test\n---\nBody"))
+	require.Error(test, err)
+}
@@ -0,0 +1,9 @@
+/*
+Package recording captures calls made to the Senzing engine as JSON Lines (JSONL)
+and replays them in place of the engine.
+
+A recording made against a live engine can be replayed later, offline,
+to reproduce a test or a bug report.  Recordings hold the search attributes
+and entity documents verbatim, so treat them as containing PII.
+*/
+package recording
@@ -0,0 +1,47 @@
+package recording
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Interaction is one recorded call.  Each Interaction is one line of a recording.
+type Interaction struct {
+	Error     string          `json:"error,omitempty"`
+	Kind      string          `json:"kind"`
+	Operation string          `json:"operation"`
+	Request   json.RawMessage `json:"request"`
+	Response  string          `json:"response"`
+	Sequence  int64           `json:"sequence"`
+	Time      time.Time       `json:"time"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Largest line, in bytes, accepted by ReadInteractions.
+const maxLineSize = 64 * 1024 * 1024
+
+// Values of Interaction.Kind.
+const (
+	KindSenzing = "senzing"
+)
+
+// Values of Interaction.Operation for KindSenzing.
+const (
+	OperationGetEntityByEntityID = "GetEntityByEntityID"
+	OperationHowEntityByEntityID = "HowEntityByEntityID"
+	OperationSearchByAttributes  = "SearchByAttributes"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("recording")
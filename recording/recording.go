@@ -0,0 +1,232 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// RecordingEntityEngine passes calls to EntityEngine and writes each call to Writer as a line of JSON.
+type RecordingEntityEngine struct {
+	EntityEngine senzingchatservice.EntityEngine
+	Writer       io.Writer
+	mutex        sync.Mutex
+	sequence     int64
+}
+
+// ReplayEntityEngine answers calls from recorded Interactions instead of a Senzing engine.
+// Identical calls are answered in the order they were recorded; the last answer is then repeated.
+type ReplayEntityEngine struct {
+	Interactions []Interaction
+	indexOnce    sync.Once
+	mutex        sync.Mutex
+	replies      map[string][]Interaction
+}
+
+type entityIDRequest struct {
+	EntityID int64 `json:"entity_id"`
+}
+
+type searchRequest struct {
+	Attributes string `json:"attributes"`
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - RecordingEntityEngine
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID calls the wrapped EntityEngine and records the call.
+func (engine *RecordingEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	result, err := engine.EntityEngine.GetEntityByEntityID(ctx, entityID)
+	engine.record(OperationGetEntityByEntityID, entityIDRequest{EntityID: entityID}, result, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// HowEntityByEntityID calls the wrapped EntityEngine and records the call.
+func (engine *RecordingEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	result, err := engine.EntityEngine.HowEntityByEntityID(ctx, entityID)
+	engine.record(OperationHowEntityByEntityID, entityIDRequest{EntityID: entityID}, result, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// SearchByAttributes calls the wrapped EntityEngine and records the call.
+func (engine *RecordingEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	result, err := engine.EntityEngine.SearchByAttributes(ctx, attributes)
+	engine.record(OperationSearchByAttributes, searchRequest{Attributes: attributes}, result, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods - ReplayEntityEngine
+// ----------------------------------------------------------------------------
+
+// CheckHealth reports that the replay is ready; it needs no Senzing factory or database.
+func (engine *ReplayEntityEngine) CheckHealth(ctx context.Context) error {
+	_ = ctx
+
+	return nil
+}
+
+// GetEntityByEntityID returns the recorded answer.
+func (engine *ReplayEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	return engine.replay(OperationGetEntityByEntityID, entityIDRequest{EntityID: entityID})
+}
+
+// HowEntityByEntityID returns the recorded answer.
+func (engine *ReplayEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	return engine.replay(OperationHowEntityByEntityID, entityIDRequest{EntityID: entityID})
+}
+
+// SearchByAttributes returns the recorded answer.
+func (engine *ReplayEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	_ = ctx
+
+	return engine.replay(OperationSearchByAttributes, searchRequest{Attributes: attributes})
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The ReadInteractions function reads a JSONL recording.
+
+Input
+  - reader: The source of the recording.
+
+Output
+  - The Interactions, in the order they were recorded.
+*/
+func ReadInteractions(reader io.Reader) ([]Interaction, error) {
+	var result []Interaction
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var interaction Interaction
+
+		err := json.Unmarshal(scanner.Bytes(), &interaction)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "json.Unmarshal line %d", lineNumber)
+		}
+
+		result = append(result, interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, wraperror.Errorf(err, "scanner.Err")
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *RecordingEntityEngine) record(operation string, request any, response string, err error) {
+	requestJSON, marshalErr := json.Marshal(request)
+	if marshalErr != nil {
+		return
+	}
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	engine.sequence++
+	interaction := Interaction{
+		Error:     "",
+		Kind:      KindSenzing,
+		Operation: operation,
+		Request:   requestJSON,
+		Response:  response,
+		Sequence:  engine.sequence,
+		Time:      time.Now().UTC(),
+	}
+
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+
+	line, marshalErr := json.Marshal(interaction)
+	if marshalErr != nil {
+		return
+	}
+
+	_, _ = engine.Writer.Write(append(line, '\n'))
+}
+
+func (engine *ReplayEntityEngine) replay(operation string, request any) (string, error) {
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return "", wraperror.Errorf(err, "json.Marshal")
+	}
+
+	engine.indexOnce.Do(engine.index)
+
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	key := replayKey(KindSenzing, operation, requestJSON)
+
+	replies := engine.replies[key]
+	if len(replies) == 0 {
+		return "", wraperror.Errorf(errForPackage, "no recording for %s", operation)
+	}
+
+	reply := replies[0]
+	if len(replies) > 1 {
+		engine.replies[key] = replies[1:]
+	}
+
+	if len(reply.Error) > 0 {
+		return reply.Response, wraperror.Errorf(errForPackage, "recorded error: %s", reply.Error)
+	}
+
+	return reply.Response, nil
+}
+
+func (engine *ReplayEntityEngine) index() {
+	engine.replies = map[string][]Interaction{}
+	for _, interaction := range engine.Interactions {
+		key := replayKey(interaction.Kind, interaction.Operation, interaction.Request)
+		engine.replies[key] = append(engine.replies[key], interaction)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func replayKey(kind string, operation string, request json.RawMessage) string {
+	var compacted bytes.Buffer
+
+	if json.Compact(&compacted, request) != nil {
+		return kind + " " + operation + " " + string(request)
+	}
+
+	return kind + " " + operation + " " + compacted.String()
+}
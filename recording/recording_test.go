@@ -0,0 +1,100 @@
+package recording_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/internal/enginetest"
+	"github.com/senzing-garage/serve-chat/recording"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRecordingEntityEngine_replay(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+
+	var recorded bytes.Buffer
+
+	recorder := &recording.RecordingEntityEngine{
+		EntityEngine: &enginetest.EntityEngine{ //nolint:exhaustruct
+			EntityJSON: `{"RESOLVED_ENTITY": {"ENTITY_ID": 1}}`,
+			HowJSON:    `{"HOW_RESULTS": {}}`,
+			SearchJSON: `{"RESOLVED_ENTITIES": []}`,
+		},
+		Writer: &recorded,
+	}
+	entityJSON, err := recorder.GetEntityByEntityID(ctx, 1)
+	require.NoError(test, err)
+	howJSON, err := recorder.HowEntityByEntityID(ctx, 1)
+	require.NoError(test, err)
+	searchJSON, err := recorder.SearchByAttributes(ctx, `{"NAME_FULL": "Robert Smith"}`)
+	require.NoError(test, err)
+
+	interactions, err := recording.ReadInteractions(&recorded)
+	require.NoError(test, err)
+	require.Len(test, interactions, 3)
+	require.Equal(test, recording.OperationSearchByAttributes, interactions[2].Operation)
+	require.Equal(test, int64(3), interactions[2].Sequence)
+
+	replayer := &recording.ReplayEntityEngine{Interactions: interactions}
+	actual, err := replayer.GetEntityByEntityID(ctx, 1)
+	require.NoError(test, err)
+	require.Equal(test, entityJSON, actual)
+	actual, err = replayer.HowEntityByEntityID(ctx, 1)
+	require.NoError(test, err)
+	require.Equal(test, howJSON, actual)
+	actual, err = replayer.SearchByAttributes(ctx, `{"NAME_FULL": "Robert Smith"}`)
+	require.NoError(test, err)
+	require.Equal(test, searchJSON, actual)
+}
+
+func TestReplayEntityEngine_CheckHealth(test *testing.T) {
+	test.Parallel()
+	replayer := &recording.ReplayEntityEngine{}
+	require.NoError(test, replayer.CheckHealth(test.Context()))
+}
+
+func TestReplayEntityEngine_missing(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	replayer := &recording.ReplayEntityEngine{}
+	_, err := replayer.GetEntityByEntityID(ctx, 1)
+	require.Error(test, err)
+}
+
+func TestReplayEntityEngine_order(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	recordingJSONL := `{"kind": "senzing", "operation": "GetEntityByEntityID", "request": {"entity_id": 1}, "response": "first"}
+
+{"kind": "senzing", "operation": "GetEntityByEntityID", "request": {"entity_id": 1}, "response": "second"}
+{"kind": "senzing", "operation": "GetEntityByEntityID", "request": {"entity_id": 2}, "error": "not found"}
+`
+	interactions, err := recording.ReadInteractions(bytes.NewBufferString(recordingJSONL))
+	require.NoError(test, err)
+
+	replayer := &recording.ReplayEntityEngine{Interactions: interactions}
+
+	for _, expected := range []string{"first", "second", "second"} {
+		actual, err := replayer.GetEntityByEntityID(ctx, 1)
+		require.NoError(test, err)
+		require.Equal(test, expected, actual)
+	}
+
+	_, err = replayer.GetEntityByEntityID(ctx, 2)
+	require.Error(test, err)
+}
+
+// ----------------------------------------------------------------------------
+// Test public functions
+// ----------------------------------------------------------------------------
+
+func TestReadInteractions_badJSON(test *testing.T) {
+	test.Parallel()
+	_, err := recording.ReadInteractions(bytes.NewBufferString("}{\n"))
+	require.Error(test, err)
+}
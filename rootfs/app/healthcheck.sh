@@ -5,8 +5,32 @@
 OK=0
 NOT_OK=1
 
-# Tests.
+# Configuration.  Match the serve-chat command line environment variables.
 
-echo "Doing health test."
+HTTP_PORT=${SENZING_TOOLS_HTTP_PORT:-8260}
+SCHEME="http"
+CURL_OPTIONS=(--silent --show-error --max-time 10)
+
+if [[ -n "${SENZING_TOOLS_SERVER_CERTIFICATE_FILE}" ]]; then
+    SCHEME="https"
+
+    # The health check runs on localhost, which the server certificate need not name.
+
+    CURL_OPTIONS+=(--insecure)
+    if [[ -n "${SENZING_TOOLS_HEALTHCHECK_CLIENT_CERTIFICATE_FILE}" ]]; then
+        CURL_OPTIONS+=(--cert "${SENZING_TOOLS_HEALTHCHECK_CLIENT_CERTIFICATE_FILE}")
+        CURL_OPTIONS+=(--key "${SENZING_TOOLS_HEALTHCHECK_CLIENT_KEY_FILE}")
+    fi
+fi
+
+BASE_URL="${SCHEME}://localhost:${HTTP_PORT}"
+
+# Tests.  Only liveness: readiness, which depends on Senzing, is for the orchestrator to probe at /readyz.
+
+if ! RESPONSE=$(curl "${CURL_OPTIONS[@]}" --fail-with-body "${BASE_URL}/livez"); then
+    echo "Health test failed: /livez"
+    echo "${RESPONSE}"
+    exit ${NOT_OK}
+fi
 
 exit ${OK}
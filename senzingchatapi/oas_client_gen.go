@@ -12,7 +12,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
-	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ogen-go/ogen/conv"
@@ -21,6 +21,11 @@ import (
 	"github.com/ogen-go/ogen/uri"
 )
 
+func trimTrailingSlashes(u *url.URL) {
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+}
+
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
 	// EntityDetailsEntityDetailsGet invokes entity_details_entity_details_get operation.
@@ -59,11 +64,6 @@ var _ Handler = struct {
 	*Client
 }{}
 
-func trimTrailingSlashes(u *url.URL) {
-	u.Path = strings.TrimRight(u.Path, "/")
-	u.RawPath = strings.TrimRight(u.RawPath, "/")
-}
-
 // NewClient initializes new Client defined by OAS.
 func NewClient(serverURL string, opts ...ClientOption) (*Client, error) {
 	u, err := url.Parse(serverURL)
@@ -110,7 +110,7 @@ func (c *Client) EntityDetailsEntityDetailsGet(ctx context.Context, params Entit
 func (c *Client) sendEntityDetailsEntityDetailsGet(ctx context.Context, params EntityDetailsEntityDetailsGetParams) (res EntityDetailsEntityDetailsGetRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		otelogen.OperationID("entity_details_entity_details_get"),
-		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRequestMethodKey.String("GET"),
 		semconv.HTTPRouteKey.String("/entity_details"),
 	}
 
@@ -119,14 +119,14 @@ func (c *Client) sendEntityDetailsEntityDetailsGet(ctx context.Context, params E
 	defer func() {
 		// Use floating point division here for higher precision (instead of Millisecond method).
 		elapsedDuration := time.Since(startTime)
-		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
 	}()
 
 	// Increment request counter.
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "EntityDetailsEntityDetailsGet",
+	ctx, span := c.cfg.Tracer.Start(ctx, EntityDetailsEntityDetailsGetOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -163,6 +163,40 @@ func (c *Client) sendEntityDetailsEntityDetailsGet(ctx context.Context, params E
 			return res, errors.Wrap(err, "encode query")
 		}
 	}
+	{
+		// Encode "format" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "format",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Format.Get(); ok {
+				return e.EncodeValue(conv.StringToString(string(val)))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
+	{
+		// Encode "locale" parameter.
+		cfg := uri.QueryParameterEncodingConfig{
+			Name:    "locale",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.EncodeParam(cfg, func(e uri.Encoder) error {
+			if val, ok := params.Locale.Get(); ok {
+				return e.EncodeValue(conv.StringToString(val))
+			}
+			return nil
+		}); err != nil {
+			return res, errors.Wrap(err, "encode query")
+		}
+	}
 	u.RawQuery = q.Values().Encode()
 
 	stage = "EncodeRequest"
@@ -200,7 +234,7 @@ func (c *Client) EntityHowEntityHowGet(ctx context.Context, params EntityHowEnti
 func (c *Client) sendEntityHowEntityHowGet(ctx context.Context, params EntityHowEntityHowGetParams) (res EntityHowEntityHowGetRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		otelogen.OperationID("entity_how_entity_how_get"),
-		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRequestMethodKey.String("GET"),
 		semconv.HTTPRouteKey.String("/entity_how"),
 	}
 
@@ -209,14 +243,14 @@ func (c *Client) sendEntityHowEntityHowGet(ctx context.Context, params EntityHow
 	defer func() {
 		// Use floating point division here for higher precision (instead of Millisecond method).
 		elapsedDuration := time.Since(startTime)
-		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
 	}()
 
 	// Increment request counter.
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "EntityHowEntityHowGet",
+	ctx, span := c.cfg.Tracer.Start(ctx, EntityHowEntityHowGetOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -290,7 +324,7 @@ func (c *Client) EntityReportEntityReportGet(ctx context.Context, params EntityR
 func (c *Client) sendEntityReportEntityReportGet(ctx context.Context, params EntityReportEntityReportGetParams) (res EntityReportEntityReportGetRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		otelogen.OperationID("entity_report_entity_report_get"),
-		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPRequestMethodKey.String("GET"),
 		semconv.HTTPRouteKey.String("/entity_report"),
 	}
 
@@ -299,14 +333,14 @@ func (c *Client) sendEntityReportEntityReportGet(ctx context.Context, params Ent
 	defer func() {
 		// Use floating point division here for higher precision (instead of Millisecond method).
 		elapsedDuration := time.Since(startTime)
-		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
 	}()
 
 	// Increment request counter.
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "EntityReportEntityReportGet",
+	ctx, span := c.cfg.Tracer.Start(ctx, EntityReportEntityReportGetOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
@@ -380,7 +414,7 @@ func (c *Client) EntitySearchEntitySearchPost(ctx context.Context, request *Sear
 func (c *Client) sendEntitySearchEntitySearchPost(ctx context.Context, request *SearchAttributes) (res EntitySearchEntitySearchPostRes, err error) {
 	otelAttrs := []attribute.KeyValue{
 		otelogen.OperationID("entity_search_entity_search_post"),
-		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPRequestMethodKey.String("POST"),
 		semconv.HTTPRouteKey.String("/entity_search"),
 	}
 
@@ -389,14 +423,14 @@ func (c *Client) sendEntitySearchEntitySearchPost(ctx context.Context, request *
 	defer func() {
 		// Use floating point division here for higher precision (instead of Millisecond method).
 		elapsedDuration := time.Since(startTime)
-		c.duration.Record(ctx, float64(float64(elapsedDuration)/float64(time.Millisecond)), metric.WithAttributes(otelAttrs...))
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
 	}()
 
 	// Increment request counter.
 	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
 
 	// Start a span for this request.
-	ctx, span := c.cfg.Tracer.Start(ctx, "EntitySearchEntitySearchPost",
+	ctx, span := c.cfg.Tracer.Start(ctx, EntitySearchEntitySearchPostOperation,
 		trace.WithAttributes(otelAttrs...),
 		clientSpanKind,
 	)
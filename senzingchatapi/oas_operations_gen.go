@@ -0,0 +1,13 @@
+// Code generated by ogen, DO NOT EDIT.
+
+package senzingchatapi
+
+// OperationName is the ogen operation name
+type OperationName = string
+
+const (
+	EntityDetailsEntityDetailsGetOperation OperationName = "EntityDetailsEntityDetailsGet"
+	EntityHowEntityHowGetOperation         OperationName = "EntityHowEntityHowGet"
+	EntityReportEntityReportGetOperation   OperationName = "EntityReportEntityReportGet"
+	EntitySearchEntitySearchPostOperation  OperationName = "EntitySearchEntitySearchPost"
+)
@@ -9,12 +9,16 @@ import (
 	"github.com/ogen-go/ogen/middleware"
 	"github.com/ogen-go/ogen/ogenerrors"
 	"github.com/ogen-go/ogen/uri"
-	"github.com/ogen-go/ogen/validate"
 )
 
 // EntityDetailsEntityDetailsGetParams is parameters of entity_details_entity_details_get operation.
 type EntityDetailsEntityDetailsGetParams struct {
 	EntityID int
+	// Response format. "json" returns the Senzing entity document; "narrative" returns a one-paragraph
+	// profile of the entity.
+	Format OptEntityDetailsFormat
+	// BCP 47 language tag used to format dates in the narrative.
+	Locale OptString
 }
 
 func unpackEntityDetailsEntityDetailsGetParams(packed middleware.Parameters) (params EntityDetailsEntityDetailsGetParams) {
@@ -25,6 +29,24 @@ func unpackEntityDetailsEntityDetailsGetParams(packed middleware.Parameters) (pa
 		}
 		params.EntityID = packed[key].(int)
 	}
+	{
+		key := middleware.ParameterKey{
+			Name: "format",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Format = v.(OptEntityDetailsFormat)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "locale",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Locale = v.(OptString)
+		}
+	}
 	return params
 }
 
@@ -56,7 +78,7 @@ func decodeEntityDetailsEntityDetailsGetParams(args [0]string, argsEscaped bool,
 				return err
 			}
 		} else {
-			return validate.ErrFieldRequired
+			return err
 		}
 		return nil
 	}(); err != nil {
@@ -66,6 +88,113 @@ func decodeEntityDetailsEntityDetailsGetParams(args [0]string, argsEscaped bool,
 			Err:  err,
 		}
 	}
+	// Set default value for query: format.
+	{
+		val := EntityDetailsFormat("json")
+		params.Format.SetTo(val)
+	}
+	// Decode query: format.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "format",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotFormatVal EntityDetailsFormat
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotFormatVal = EntityDetailsFormat(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Format.SetTo(paramsDotFormatVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if value, ok := params.Format.Get(); ok {
+					if err := func() error {
+						if err := value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "format",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Set default value for query: locale.
+	{
+		val := string("en-US")
+		params.Locale.SetTo(val)
+	}
+	// Decode query: locale.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "locale",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotLocaleVal string
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotLocaleVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Locale.SetTo(paramsDotLocaleVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "locale",
+			In:   "query",
+			Err:  err,
+		}
+	}
 	return params, nil
 }
 
@@ -113,7 +242,7 @@ func decodeEntityHowEntityHowGetParams(args [0]string, argsEscaped bool, r *http
 				return err
 			}
 		} else {
-			return validate.ErrFieldRequired
+			return err
 		}
 		return nil
 	}(); err != nil {
@@ -178,7 +307,7 @@ func decodeEntityReportEntityReportGetParams(args [0]string, argsEscaped bool, r
 				return err
 			}
 		} else {
-			return validate.ErrFieldRequired
+			return err
 		}
 		return nil
 	}(); err != nil {
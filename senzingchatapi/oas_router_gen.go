@@ -49,7 +49,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 		switch elem[0] {
 		case '/': // Prefix: "/entity_"
-			origElem := elem
+
 			if l := len("/entity_"); len(elem) >= l && elem[0:l] == "/entity_" {
 				elem = elem[l:]
 			} else {
@@ -61,7 +61,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}
 			switch elem[0] {
 			case 'd': // Prefix: "details"
-				origElem := elem
+
 				if l := len("details"); len(elem) >= l && elem[0:l] == "details" {
 					elem = elem[l:]
 				} else {
@@ -80,9 +80,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-				elem = origElem
 			case 'h': // Prefix: "how"
-				origElem := elem
+
 				if l := len("how"); len(elem) >= l && elem[0:l] == "how" {
 					elem = elem[l:]
 				} else {
@@ -101,9 +100,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-				elem = origElem
 			case 'r': // Prefix: "report"
-				origElem := elem
+
 				if l := len("report"); len(elem) >= l && elem[0:l] == "report" {
 					elem = elem[l:]
 				} else {
@@ -122,9 +120,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-				elem = origElem
 			case 's': // Prefix: "search"
-				origElem := elem
+
 				if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
 					elem = elem[l:]
 				} else {
@@ -143,10 +140,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 
-				elem = origElem
 			}
 
-			elem = origElem
 		}
 	}
 	s.notFound(w, r)
@@ -228,7 +223,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 		}
 		switch elem[0] {
 		case '/': // Prefix: "/entity_"
-			origElem := elem
+
 			if l := len("/entity_"); len(elem) >= l && elem[0:l] == "/entity_" {
 				elem = elem[l:]
 			} else {
@@ -240,7 +235,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 			}
 			switch elem[0] {
 			case 'd': // Prefix: "details"
-				origElem := elem
+
 				if l := len("details"); len(elem) >= l && elem[0:l] == "details" {
 					elem = elem[l:]
 				} else {
@@ -251,7 +246,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					// Leaf node.
 					switch method {
 					case "GET":
-						r.name = "EntityDetailsEntityDetailsGet"
+						r.name = EntityDetailsEntityDetailsGetOperation
 						r.summary = "Entity Details"
 						r.operationID = "entity_details_entity_details_get"
 						r.pathPattern = "/entity_details"
@@ -263,9 +258,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-				elem = origElem
 			case 'h': // Prefix: "how"
-				origElem := elem
+
 				if l := len("how"); len(elem) >= l && elem[0:l] == "how" {
 					elem = elem[l:]
 				} else {
@@ -276,7 +270,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					// Leaf node.
 					switch method {
 					case "GET":
-						r.name = "EntityHowEntityHowGet"
+						r.name = EntityHowEntityHowGetOperation
 						r.summary = "Entity How"
 						r.operationID = "entity_how_entity_how_get"
 						r.pathPattern = "/entity_how"
@@ -288,9 +282,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-				elem = origElem
 			case 'r': // Prefix: "report"
-				origElem := elem
+
 				if l := len("report"); len(elem) >= l && elem[0:l] == "report" {
 					elem = elem[l:]
 				} else {
@@ -301,7 +294,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					// Leaf node.
 					switch method {
 					case "GET":
-						r.name = "EntityReportEntityReportGet"
+						r.name = EntityReportEntityReportGetOperation
 						r.summary = "Entity Report"
 						r.operationID = "entity_report_entity_report_get"
 						r.pathPattern = "/entity_report"
@@ -313,9 +306,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-				elem = origElem
 			case 's': // Prefix: "search"
-				origElem := elem
+
 				if l := len("search"); len(elem) >= l && elem[0:l] == "search" {
 					elem = elem[l:]
 				} else {
@@ -326,7 +318,7 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					// Leaf node.
 					switch method {
 					case "POST":
-						r.name = "EntitySearchEntitySearchPost"
+						r.name = EntitySearchEntitySearchPostOperation
 						r.summary = "Entity Search"
 						r.operationID = "entity_search_entity_search_post"
 						r.pathPattern = "/entity_search"
@@ -338,10 +330,8 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 					}
 				}
 
-				elem = origElem
 			}
 
-			elem = origElem
 		}
 	}
 	return r, false
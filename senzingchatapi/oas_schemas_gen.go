@@ -7,22 +7,170 @@ import (
 	"github.com/go-faster/jx"
 )
 
-type EntityDetailsEntityDetailsGetOK struct{}
+type EntityDetailsEntityDetailsGetOK struct {
+	// One-paragraph profile of the entity. Only present when format is "narrative".
+	Narrative       OptString `json:"narrative"`
+	AdditionalProps EntityDetailsEntityDetailsGetOKAdditional
+}
+
+// GetNarrative returns the value of Narrative.
+func (s *EntityDetailsEntityDetailsGetOK) GetNarrative() OptString {
+	return s.Narrative
+}
+
+// GetAdditionalProps returns the value of AdditionalProps.
+func (s *EntityDetailsEntityDetailsGetOK) GetAdditionalProps() EntityDetailsEntityDetailsGetOKAdditional {
+	return s.AdditionalProps
+}
+
+// SetNarrative sets the value of Narrative.
+func (s *EntityDetailsEntityDetailsGetOK) SetNarrative(val OptString) {
+	s.Narrative = val
+}
+
+// SetAdditionalProps sets the value of AdditionalProps.
+func (s *EntityDetailsEntityDetailsGetOK) SetAdditionalProps(val EntityDetailsEntityDetailsGetOKAdditional) {
+	s.AdditionalProps = val
+}
 
 func (*EntityDetailsEntityDetailsGetOK) entityDetailsEntityDetailsGetRes() {}
 
-type EntityHowEntityHowGetOK struct{}
+type EntityDetailsEntityDetailsGetOKAdditional map[string]jx.Raw
+
+func (s *EntityDetailsEntityDetailsGetOKAdditional) init() EntityDetailsEntityDetailsGetOKAdditional {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
+// An enumeration.
+// Ref: #/components/schemas/EntityDetailsFormat
+type EntityDetailsFormat string
+
+const (
+	EntityDetailsFormatJSON      EntityDetailsFormat = "json"
+	EntityDetailsFormatNarrative EntityDetailsFormat = "narrative"
+)
+
+// AllValues returns all EntityDetailsFormat values.
+func (EntityDetailsFormat) AllValues() []EntityDetailsFormat {
+	return []EntityDetailsFormat{
+		EntityDetailsFormatJSON,
+		EntityDetailsFormatNarrative,
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s EntityDetailsFormat) MarshalText() ([]byte, error) {
+	switch s {
+	case EntityDetailsFormatJSON:
+		return []byte(s), nil
+	case EntityDetailsFormatNarrative:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *EntityDetailsFormat) UnmarshalText(data []byte) error {
+	switch EntityDetailsFormat(data) {
+	case EntityDetailsFormatJSON:
+		*s = EntityDetailsFormatJSON
+		return nil
+	case EntityDetailsFormatNarrative:
+		*s = EntityDetailsFormatNarrative
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+type EntityHowEntityHowGetOK struct {
+	// Plain-English explanation of each resolution step.
+	Explanation     OptString `json:"explanation"`
+	AdditionalProps EntityHowEntityHowGetOKAdditional
+}
+
+// GetExplanation returns the value of Explanation.
+func (s *EntityHowEntityHowGetOK) GetExplanation() OptString {
+	return s.Explanation
+}
+
+// GetAdditionalProps returns the value of AdditionalProps.
+func (s *EntityHowEntityHowGetOK) GetAdditionalProps() EntityHowEntityHowGetOKAdditional {
+	return s.AdditionalProps
+}
+
+// SetExplanation sets the value of Explanation.
+func (s *EntityHowEntityHowGetOK) SetExplanation(val OptString) {
+	s.Explanation = val
+}
+
+// SetAdditionalProps sets the value of AdditionalProps.
+func (s *EntityHowEntityHowGetOK) SetAdditionalProps(val EntityHowEntityHowGetOKAdditional) {
+	s.AdditionalProps = val
+}
 
 func (*EntityHowEntityHowGetOK) entityHowEntityHowGetRes() {}
 
+type EntityHowEntityHowGetOKAdditional map[string]jx.Raw
+
+func (s *EntityHowEntityHowGetOKAdditional) init() EntityHowEntityHowGetOKAdditional {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
 type EntityReportEntityReportGetOKApplicationJSON []jx.Raw
 
 func (*EntityReportEntityReportGetOKApplicationJSON) entityReportEntityReportGetRes() {}
 
-type EntitySearchEntitySearchPostOK struct{}
+type EntitySearchEntitySearchPostOK struct {
+	// Plain-English explanation of why each entity matched the search.
+	Explanation     OptString `json:"explanation"`
+	AdditionalProps EntitySearchEntitySearchPostOKAdditional
+}
+
+// GetExplanation returns the value of Explanation.
+func (s *EntitySearchEntitySearchPostOK) GetExplanation() OptString {
+	return s.Explanation
+}
+
+// GetAdditionalProps returns the value of AdditionalProps.
+func (s *EntitySearchEntitySearchPostOK) GetAdditionalProps() EntitySearchEntitySearchPostOKAdditional {
+	return s.AdditionalProps
+}
+
+// SetExplanation sets the value of Explanation.
+func (s *EntitySearchEntitySearchPostOK) SetExplanation(val OptString) {
+	s.Explanation = val
+}
+
+// SetAdditionalProps sets the value of AdditionalProps.
+func (s *EntitySearchEntitySearchPostOK) SetAdditionalProps(val EntitySearchEntitySearchPostOKAdditional) {
+	s.AdditionalProps = val
+}
 
 func (*EntitySearchEntitySearchPostOK) entitySearchEntitySearchPostRes() {}
 
+type EntitySearchEntitySearchPostOKAdditional map[string]jx.Raw
+
+func (s *EntitySearchEntitySearchPostOKAdditional) init() EntitySearchEntitySearchPostOKAdditional {
+	m := *s
+	if m == nil {
+		m = map[string]jx.Raw{}
+		*s = m
+	}
+	return m
+}
+
 // An enumeration.
 // Ref: #/components/schemas/ExportFlags
 type ExportFlags string
@@ -93,6 +241,52 @@ func (*HTTPValidationError) entityHowEntityHowGetRes()         {}
 func (*HTTPValidationError) entityReportEntityReportGetRes()   {}
 func (*HTTPValidationError) entitySearchEntitySearchPostRes()  {}
 
+// NewOptEntityDetailsFormat returns new OptEntityDetailsFormat with value set to v.
+func NewOptEntityDetailsFormat(v EntityDetailsFormat) OptEntityDetailsFormat {
+	return OptEntityDetailsFormat{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptEntityDetailsFormat is optional EntityDetailsFormat.
+type OptEntityDetailsFormat struct {
+	Value EntityDetailsFormat
+	Set   bool
+}
+
+// IsSet returns true if OptEntityDetailsFormat was set.
+func (o OptEntityDetailsFormat) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptEntityDetailsFormat) Reset() {
+	var v EntityDetailsFormat
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptEntityDetailsFormat) SetTo(v EntityDetailsFormat) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptEntityDetailsFormat) Get() (v EntityDetailsFormat, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptEntityDetailsFormat) Or(d EntityDetailsFormat) EntityDetailsFormat {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptString returns new OptString with value set to v.
 func NewOptString(v string) OptString {
 	return OptString{
@@ -11,6 +11,17 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+func (s EntityDetailsFormat) Validate() error {
+	switch s {
+	case "json":
+		return nil
+	case "narrative":
+		return nil
+	default:
+		return errors.Errorf("invalid value: %v", s)
+	}
+}
+
 func (s EntityReportEntityReportGetOKApplicationJSON) Validate() error {
 	alias := ([]jx.Raw)(s)
 	if alias == nil {
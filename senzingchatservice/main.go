@@ -1,7 +1,9 @@
 package senzingchatservice
 
 import (
+	"context"
 	_ "embed"
+	"errors"
 
 	"github.com/senzing-garage/serve-chat/senzingchatapi"
 )
@@ -15,6 +17,14 @@ type ChatAPIService interface {
 	senzingchatapi.Handler
 }
 
+// The EntityEngine interface is the part of the Senzing engine used by the service.
+// Each method returns the Senzing JSON document produced using the default flags.
+type EntityEngine interface {
+	GetEntityByEntityID(ctx context.Context, entityID int64) (string, error)
+	HowEntityByEntityID(ctx context.Context, entityID int64) (string, error)
+	SearchByAttributes(ctx context.Context, attributes string) (string, error)
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -23,6 +33,18 @@ type ChatAPIService interface {
 // See https://github.com/senzing-garage/knowledge-base/blob/main/lists/senzing-component-ids.md
 const ComponentID = 6620
 
+// Message numbers used when logging.
+const (
+	MessageAuthenticationFailed = 3001
+	MessageForbidden            = 3002
+	MessageNotFound             = 3003
+	MessageRequestFailed        = 4001
+	MessageOpenAPIFailed        = 4002
+	MessagePageFailed           = 4003
+	MessageNarrativeFailed      = 4004
+	MessageRecoveredPanic       = 6001
+)
+
 // Log message prefix.
 const Prefix = "serve-chat.chatapiservice."
 
@@ -30,15 +52,32 @@ const Prefix = "serve-chat.chatapiservice."
 // Variables
 // ----------------------------------------------------------------------------
 
+// ErrForbidden is wrapped by an EntityEngine that refuses an operation to the caller.
+// ErrorHandler responds to it with 403 Forbidden.
+// Its message is JSON, so wraperror.Errorf keeps it in the chain of wrapped errors.
+var ErrForbidden = errors.New(`{"error": "forbidden"}`)
+
+// ErrNotFound is wrapped by an EntityEngine asked for an entity that does not exist.
+// ErrorHandler responds to it with 404 Not Found.
+var ErrNotFound = errors.New(`{"error": "not found"}`)
+
 // Message templates for szconfig implementations.
 var IDMessages = map[int]string{
 	0o001: "Example Trace log.",
 	1000:  "Example Debug log.",
 	2000:  "Example Info log.",
 	3000:  "Example Warn log.",
+	3001:  "%s %s not authenticated: %v",
+	3002:  "%s %s forbidden: %v",
+	3003:  "%s %s not found: %v",
 	4000:  "Example Error log.",
+	4001:  "%s %s failed: %v",
+	4002:  "Cannot render OpenAPI specification: %v",
+	4003:  "Cannot render page %s: %v",
+	4004:  "Cannot build narrative of entity %d: %v",
 	5000:  "Example Fatal log.",
 	6000:  "Example Panic log.",
+	6001:  "Recovered from panic in %s %s: %v",
 }
 
 // Status strings for specific messages.
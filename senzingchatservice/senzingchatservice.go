@@ -1,8 +1,20 @@
 package senzingchatservice
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/ogenerrors"
+	"github.com/senzing-garage/go-helpers/wraperror"
 	"github.com/senzing-garage/go-observing/observer"
+	"github.com/senzing-garage/serve-chat/narrative"
+	"github.com/senzing-garage/serve-chat/promptregistry"
 	"github.com/senzing-garage/serve-chat/senzingchatapi"
+	"github.com/senzing-garage/serve-chat/translator"
 	"google.golang.org/grpc"
 )
 
@@ -15,14 +27,17 @@ type BasicChatAPIService struct {
 	senzingchatapi.UnimplementedHandler
 	// abstractFactory          senzing.SzAbstractFactory
 	// abstractFactorySyncOnce  sync.Once
+	EntityEngine    EntityEngine
 	GrpcDialOptions []grpc.DialOption
 	GrpcTarget      string
 	// logger                   logging.Logging
 	LogLevelName             string
+	NarrativeBuilder         narrative.NarrativeBuilder
 	ObserverOrigin           string
 	Observers                []observer.Observer
 	OpenAPISpecificationSpec []byte
 	Port                     int
+	PromptRegistry           promptregistry.PromptRegistry
 	Settings                 string
 	SenzingInstanceName      string
 	SenzingVerboseLogging    int64
@@ -30,6 +45,7 @@ type BasicChatAPIService struct {
 	// szEngineSyncOnce         sync.Once
 	// szProductSingleton       senzing.SzProduct
 	// szProductSyncOnce        sync.Once
+	Translator     translator.Translator
 	URLRoutePrefix string
 }
 
@@ -40,7 +56,7 @@ type BasicChatAPIService struct {
 // --- Logging ----------------------------------------------------------------
 
 // Get the Logger singleton.
-// func (chatAPIService *BasicChatAPIService) getLogger() logging.Logging {
+// func (chatAPIService *BasicChatAPIService) getLogger() (logging.Logging, error) {
 // 	var err error
 // 	if chatAPIService.logger == nil {
 // 		loggerOptions := []interface{}{
@@ -48,10 +64,10 @@ type BasicChatAPIService struct {
 // 		}
 // 		chatAPIService.logger, err = logging.NewSenzingLogger(ComponentID, IDMessages, loggerOptions...)
 // 		if err != nil {
-// 			panic(err)
+// 			return nil, wraperror.Errorf(err, "NewSenzingLogger")
 // 		}
 // 	}
-// 	return chatAPIService.logger
+// 	return chatAPIService.logger, nil
 // }
 
 // Log message.
@@ -68,7 +84,7 @@ type BasicChatAPIService struct {
 
 // --- Services ---------------------------------------------------------------
 
-// func (chatAPIService *BasicChatAPIService) getAbstractFactory(ctx context.Context) senzing.SzAbstractFactory {
+// func (chatAPIService *BasicChatAPIService) getAbstractFactory(ctx context.Context) (senzing.SzAbstractFactory, error) {
 // 	_ = ctx
 // 	var err error
 // 	chatAPIService.abstractFactorySyncOnce.Do(func() {
@@ -78,54 +94,322 @@ type BasicChatAPIService struct {
 //               chatAPIService.Settings,
 //               chatAPIService.SenzingVerboseLogging,
 //               senzing.SzInitializeWithDefaultConfiguration)
-// 			if err != nil {
-// 				panic(err)
-// 			}
-// 		} else {
-// 			grpcConnection, err := grpc.NewClient(chatAPIService.GrpcTarget, chatAPIService.GrpcDialOptions...)
-// 			if err != nil {
-// 				panic(err)
-// 			}
-// 			chatAPIService.abstractFactory, err = szfactorycreator.CreateGrpcAbstractFactory(grpcConnection)
-// 			if err != nil {
-// 				panic(err)
-// 			}
+// 			err = wraperror.Errorf(err, "CreateCoreAbstractFactory")
+// 			return
+// 		}
+// 		grpcConnection, err := grpc.NewClient(chatAPIService.GrpcTarget, chatAPIService.GrpcDialOptions...)
+// 		if err != nil {
+// 			err = wraperror.Errorf(err, "grpc.NewClient: %s", chatAPIService.GrpcTarget)
+// 			return
 // 		}
+// 		chatAPIService.abstractFactory, err = szfactorycreator.CreateGrpcAbstractFactory(grpcConnection)
+// 		err = wraperror.Errorf(err, "CreateGrpcAbstractFactory")
 // 	})
-// 	return chatAPIService.abstractFactory
+// 	return chatAPIService.abstractFactory, err
 // }
 
 // Singleton pattern for szproduct.
 // See https://medium.com/golang-issue/how-singleton-pattern-works-with-golang-2fdd61cd5a7f
-// func (chatAPIService *BasicChatAPIService) getSzengine(ctx context.Context) senzing.SzEngine {
+// func (chatAPIService *BasicChatAPIService) getSzengine(ctx context.Context) (senzing.SzEngine, error) {
 // 	var err error
 // 	chatAPIService.szEngineSyncOnce.Do(func() {
-// 		chatAPIService.szEngineSingleton, err = chatAPIService.getAbstractFactory(ctx).CreateSzEngine(ctx)
+// 		var abstractFactory senzing.SzAbstractFactory
+// 		abstractFactory, err = chatAPIService.getAbstractFactory(ctx)
 // 		if err != nil {
-// 			panic(err)
+// 			return
 // 		}
+// 		chatAPIService.szEngineSingleton, err = abstractFactory.CreateSzEngine(ctx)
+// 		err = wraperror.Errorf(err, "CreateSzEngine")
 // 	})
-// 	return chatAPIService.szEngineSingleton
+// 	return chatAPIService.szEngineSingleton, err
 // }
 
 // Singleton pattern for szproduct.
 // See https://medium.com/golang-issue/how-singleton-pattern-works-with-golang-2fdd61cd5a7f
-// func (chatAPIService *BasicChatAPIService) getSzproduct(ctx context.Context) senzing.SzProduct {
+// func (chatAPIService *BasicChatAPIService) getSzproduct(ctx context.Context) (senzing.SzProduct, error) {
 // 	var err error
 // 	chatAPIService.szProductSyncOnce.Do(func() {
-// 		chatAPIService.szProductSingleton, err = chatAPIService.getAbstractFactory(ctx).CreateSzProduct(ctx)
+// 		var abstractFactory senzing.SzAbstractFactory
+// 		abstractFactory, err = chatAPIService.getAbstractFactory(ctx)
 // 		if err != nil {
-// 			panic(err)
+// 			return
 // 		}
+// 		chatAPIService.szProductSingleton, err = abstractFactory.CreateSzProduct(ctx)
+// 		err = wraperror.Errorf(err, "CreateSzProduct")
 // 	})
-// 	return chatAPIService.szProductSingleton
+// 	return chatAPIService.szProductSingleton, err
 // }
 
+// --- Narrative --------------------------------------------------------------
+
+func (chatAPIService *BasicChatAPIService) getNarrativeBuilder() narrative.NarrativeBuilder {
+	if chatAPIService.NarrativeBuilder == nil {
+		return &narrative.BasicNarrativeBuilder{
+			PromptRegistry: chatAPIService.PromptRegistry,
+			Translator:     chatAPIService.getTranslator(),
+		}
+	}
+
+	return chatAPIService.NarrativeBuilder
+}
+
+// --- Translation ------------------------------------------------------------
+
+func (chatAPIService *BasicChatAPIService) getTranslator() translator.Translator {
+	if chatAPIService.Translator == nil {
+		return &translator.BasicTranslator{}
+	}
+
+	return chatAPIService.Translator
+}
+
 // ----------------------------------------------------------------------------
 // Interface methods
 // See https://github.com/senzing-garage/serve-chat/blob/main/senzingchatpapi/oas_unimplemented_gen.go
 // ----------------------------------------------------------------------------
 
+// EntityDetailsEntityDetailsGet implements entity_details_entity_details_get operation.
+//
+// Retrieve entity data based on the ID of a resolved identity.
+//
+// GET /entity_details
+func (chatAPIService *BasicChatAPIService) EntityDetailsEntityDetailsGet(
+	ctx context.Context,
+	params senzingchatapi.EntityDetailsEntityDetailsGetParams,
+) (senzingchatapi.EntityDetailsEntityDetailsGetRes, error) {
+	if chatAPIService.EntityEngine == nil {
+		return chatAPIService.UnimplementedHandler.EntityDetailsEntityDetailsGet(ctx, params)
+	}
+
+	entityJSON, err := chatAPIService.EntityEngine.GetEntityByEntityID(ctx, int64(params.EntityID))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "GetEntityByEntityID: %d", params.EntityID)
+	}
+
+	result := &senzingchatapi.EntityDetailsEntityDetailsGetOK{} //nolint:exhaustruct
+
+	if params.Format.Or(senzingchatapi.EntityDetailsFormatJSON) == senzingchatapi.EntityDetailsFormatNarrative {
+		narrativeText, err := chatAPIService.getNarrativeBuilder().
+			Build(ctx, entityJSON, params.Locale.Or(narrative.DefaultLocale))
+		if err != nil {
+			return nil, wraperror.Errorf(err, "Build")
+		}
+
+		result.SetNarrative(senzingchatapi.NewOptString(narrativeText))
+
+		return result, nil
+	}
+
+	err = result.UnmarshalJSON([]byte(entityJSON))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "UnmarshalJSON")
+	}
+
+	return result, nil
+}
+
+// EntityHowEntityHowGet implements entity_how_entity_how_get operation.
+//
+// Determines and details steps-by-step how records resolved to an ENTITY_ID.
+//
+// GET /entity_how
+func (chatAPIService *BasicChatAPIService) EntityHowEntityHowGet(
+	ctx context.Context,
+	params senzingchatapi.EntityHowEntityHowGetParams,
+) (senzingchatapi.EntityHowEntityHowGetRes, error) {
+	if chatAPIService.EntityEngine == nil {
+		return chatAPIService.UnimplementedHandler.EntityHowEntityHowGet(ctx, params)
+	}
+
+	howJSON, err := chatAPIService.EntityEngine.HowEntityByEntityID(ctx, int64(params.EntityID))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "HowEntityByEntityID: %d", params.EntityID)
+	}
+
+	result := &senzingchatapi.EntityHowEntityHowGetOK{} //nolint:exhaustruct
+
+	err = result.UnmarshalJSON([]byte(howJSON))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "UnmarshalJSON")
+	}
+
+	explanation, err := chatAPIService.getTranslator().ExplainHow(ctx, howJSON)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "ExplainHow")
+	}
+
+	result.SetExplanation(senzingchatapi.NewOptString(explanation))
+
+	return result, nil
+}
+
+// EntitySearchEntitySearchPost implements entity_search_entity_search_post operation.
+//
+// Retrieves entity data based on a user-specified set of entity attributes.
+//
+// POST /entity_search
+func (chatAPIService *BasicChatAPIService) EntitySearchEntitySearchPost(
+	ctx context.Context,
+	req *senzingchatapi.SearchAttributes,
+) (senzingchatapi.EntitySearchEntitySearchPostRes, error) {
+	if chatAPIService.EntityEngine == nil {
+		return chatAPIService.UnimplementedHandler.EntitySearchEntitySearchPost(ctx, req)
+	}
+
+	attributes, err := req.MarshalJSON()
+	if err != nil {
+		return nil, wraperror.Errorf(err, "MarshalJSON")
+	}
+
+	searchJSON, err := chatAPIService.EntityEngine.SearchByAttributes(ctx, string(attributes))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "SearchByAttributes")
+	}
+
+	result := &senzingchatapi.EntitySearchEntitySearchPostOK{} //nolint:exhaustruct
+
+	err = result.UnmarshalJSON([]byte(searchJSON))
+	if err != nil {
+		return nil, wraperror.Errorf(err, "UnmarshalJSON")
+	}
+
+	explanation, err := chatAPIService.getTranslator().ExplainSearch(ctx, searchJSON)
+	if err != nil {
+		return nil, wraperror.Errorf(err, "ExplainSearch")
+	}
+
+	result.SetExplanation(senzingchatapi.NewOptString(explanation))
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The ErrorHandler method writes the response for an error returned by an operation.
+It is an ogenerrors.ErrorHandler, for use with senzingchatapi.WithErrorHandler().
+Client errors are reported as ogen reports them.
+Errors wrapping ErrForbidden or ErrNotFound are logged and reported as 403 Forbidden or 404 Not Found.
+Server errors are logged; the response carries only the message ID, so details of the
+Senzing engine or of the request do not leak to the caller.
+
+Input
+  - ctx: A context to control lifecycle.
+  - writer: The response.
+  - request: The request that failed.
+  - err: The error returned while handling the request.
+*/
+func (chatAPIService *BasicChatAPIService) ErrorHandler(
+	ctx context.Context,
+	writer http.ResponseWriter,
+	request *http.Request,
+	err error,
+) {
+	if errors.Is(err, ErrForbidden) {
+		Log(ctx, MessageForbidden, request.Method, request.URL.Path, err)
+		WriteError(writer, http.StatusForbidden, MessageForbidden)
+
+		return
+	}
+
+	if errors.Is(err, ErrNotFound) {
+		Log(ctx, MessageNotFound, request.Method, request.URL.Path, err)
+		WriteError(writer, http.StatusNotFound, MessageNotFound)
+
+		return
+	}
+
+	code := ogenerrors.ErrorCode(err)
+	if code < http.StatusInternalServerError || code == http.StatusNotImplemented {
+		ogenerrors.DefaultErrorHandler(ctx, writer, request, err)
+
+		return
+	}
+
+	Log(ctx, MessageRequestFailed, request.Method, request.URL.Path, err)
+	WriteError(writer, code, MessageRequestFailed)
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Log function logs a message from IDMessages.
+The level is determined by the message number: 0-999 trace, 1000-1999 debug, 2000-2999 info,
+3000-3999 warn, 4000 and above error.
+
+Input
+  - ctx: A context to control lifecycle.
+  - messageNumber: A key of IDMessages.
+  - details: Values for the message template.
+*/
+func Log(ctx context.Context, messageNumber int, details ...any) {
+	slog.Log(
+		ctx,
+		messageLevel(messageNumber),
+		fmt.Sprintf(IDMessages[messageNumber], details...),
+		"id", MessageID(messageNumber),
+	)
+}
+
+/*
+The MessageID function returns the identifier of a message, like "senzing-66204001".
+
+Input
+  - messageNumber: A key of IDMessages.
+
+Output
+  - The message identifier.
+*/
+func MessageID(messageNumber int) string {
+	return fmt.Sprintf("senzing-%04d%04d", ComponentID, messageNumber)
+}
+
+/*
+The WriteError function writes a JSON error response naming only the status and message ID.
+
+Input
+  - writer: The response.
+  - code: The HTTP status code.
+  - messageNumber: A key of IDMessages, logged with the details of the error.
+*/
+func WriteError(writer http.ResponseWriter, code int, messageNumber int) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(code)
+
+	encoder := jx.GetEncoder()
+	defer jx.PutEncoder(encoder)
+
+	encoder.ObjStart()
+	encoder.FieldStart("error_message")
+	encoder.StrEscape(fmt.Sprintf("%s (%s)", http.StatusText(code), MessageID(messageNumber)))
+	encoder.ObjEnd()
+
+	_, _ = writer.Write(encoder.Bytes())
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func messageLevel(messageNumber int) slog.Level {
+	switch {
+	case messageNumber < 1000:
+		return slog.LevelDebug - 4 //nolint:mnd
+	case messageNumber < 2000:
+		return slog.LevelDebug
+	case messageNumber < 3000:
+		return slog.LevelInfo
+	case messageNumber < 4000:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
 // AddPet implements addPet operation.
 //
 // Add a new pet to the store.
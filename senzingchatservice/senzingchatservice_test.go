@@ -1,35 +1,239 @@
 package senzingchatservice_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/senzingchatapi"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	howJSON = `{"HOW_RESULTS": {"RESOLUTION_STEPS": [{"STEP": 1,
+		"VIRTUAL_ENTITY_1": {"VIRTUAL_ENTITY_ID": "V1"}, "VIRTUAL_ENTITY_2": {"VIRTUAL_ENTITY_ID": "V2"},
+		"RESULT_VIRTUAL_ENTITY_ID": "V1-S1", "MATCH_INFO": {"MATCH_KEY": "+NAME+DOB"}}]}}`
+	searchJSON = `{"RESOLVED_ENTITIES": [{
+		"MATCH_INFO": {"MATCH_LEVEL_CODE": "RESOLVED", "MATCH_KEY": "+NAME+DOB-SSN"},
+		"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 1, "ENTITY_NAME": "Robert Smith"}}}]}`
 )
 
 var (
 // chatAPIServiceSingleton ChatAPIService
 )
 
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicChatAPIService_AddPet(test *testing.T) {
+	test.Parallel()
+}
+
+func TestBasicChatAPIService_EntityDetailsEntityDetailsGet(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(test)
+	response, err := testObject.EntityDetailsEntityDetailsGet(
+		ctx,
+		senzingchatapi.EntityDetailsEntityDetailsGetParams{EntityID: 1},
+	)
+	require.NoError(test, err)
+	result, isOK := response.(*senzingchatapi.EntityDetailsEntityDetailsGetOK)
+	require.True(test, isOK)
+	require.False(test, result.Narrative.IsSet())
+	require.Contains(test, result.AdditionalProps, "RESOLVED_ENTITY")
+}
+
+func TestBasicChatAPIService_EntityDetailsEntityDetailsGet_narrative(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(test)
+	response, err := testObject.EntityDetailsEntityDetailsGet(ctx, senzingchatapi.EntityDetailsEntityDetailsGetParams{
+		EntityID: 1,
+		Format:   senzingchatapi.NewOptEntityDetailsFormat(senzingchatapi.EntityDetailsFormatNarrative),
+		Locale:   senzingchatapi.NewOptString("en-US"),
+	})
+	require.NoError(test, err)
+	result, isOK := response.(*senzingchatapi.EntityDetailsEntityDetailsGetOK)
+	require.True(test, isOK)
+	require.Equal(test, "Robert Smith.", result.Narrative.Value)
+	require.Empty(test, result.AdditionalProps)
+}
+
+func TestBasicChatAPIService_EntityHowEntityHowGet(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(test)
+	response, err := testObject.EntityHowEntityHowGet(ctx, senzingchatapi.EntityHowEntityHowGetParams{EntityID: 1})
+	require.NoError(test, err)
+	result, isOK := response.(*senzingchatapi.EntityHowEntityHowGetOK)
+	require.True(test, isOK)
+	require.Equal(test, "Step 1: V1 and V2 were combined into V1-S1 because of same name and date of birth.",
+		result.Explanation.Value)
+	require.Contains(test, result.AdditionalProps, "HOW_RESULTS")
+}
+
+func TestBasicChatAPIService_EntitySearchEntitySearchPost(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := getTestObject(test)
+	request := &senzingchatapi.SearchAttributes{
+		NAMEFULL: senzingchatapi.NewOptString("Robert Smith"),
+	}
+	response, err := testObject.EntitySearchEntitySearchPost(ctx, request)
+	require.NoError(test, err)
+	result, isOK := response.(*senzingchatapi.EntitySearchEntitySearchPostOK)
+	require.True(test, isOK)
+	require.Equal(test, "Entity 1 (Robert Smith) is a match: same name and date of birth, but different SSN.",
+		result.Explanation.Value)
+	require.Contains(test, result.AdditionalProps, "RESOLVED_ENTITIES")
+}
+
+func TestBasicChatAPIService_EntitySearchEntitySearchPost_noEngine(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &senzingchatservice.BasicChatAPIService{}
+	_, err := testObject.EntitySearchEntitySearchPost(ctx, &senzingchatapi.SearchAttributes{})
+	require.Error(test, err)
+}
+
+func TestBasicChatAPIService_ErrorHandler(test *testing.T) {
+	test.Parallel()
+	testObject := &senzingchatservice.BasicChatAPIService{
+		EntityEngine: &failingEntityEngine{},
+	}
+	server, err := senzingchatapi.NewServer(testObject, senzingchatapi.WithErrorHandler(testObject.ErrorHandler))
+	require.NoError(test, err)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequestWithContext(test.Context(), http.MethodGet,
+		"/entity_details?entity_id=1", nil))
+	require.Equal(test, http.StatusInternalServerError, recorder.Code)
+	require.Contains(test, recorder.Body.String(), "senzing-66204001")
+	require.NotContains(test, recorder.Body.String(), "engine detail")
+}
+
+func TestBasicChatAPIService_ErrorHandler_badRequest(test *testing.T) {
+	test.Parallel()
+	testObject := getTestObject(test)
+	server, err := senzingchatapi.NewServer(testObject, senzingchatapi.WithErrorHandler(testObject.ErrorHandler))
+	require.NoError(test, err)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequestWithContext(test.Context(), http.MethodGet,
+		"/entity_details?entity_id=abc", nil))
+	require.Equal(test, http.StatusBadRequest, recorder.Code)
+	require.Contains(test, recorder.Body.String(), "entity_id")
+}
+
+func TestBasicChatAPIService_ErrorHandler_forbidden(test *testing.T) {
+	test.Parallel()
+	testObject := &senzingchatservice.BasicChatAPIService{
+		EntityEngine: &forbiddenEntityEngine{},
+	}
+	server, err := senzingchatapi.NewServer(testObject, senzingchatapi.WithErrorHandler(testObject.ErrorHandler))
+	require.NoError(test, err)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequestWithContext(test.Context(), http.MethodGet,
+		"/entity_details?entity_id=1", nil))
+	require.Equal(test, http.StatusForbidden, recorder.Code)
+	require.Contains(test, recorder.Body.String(), "senzing-66203002")
+}
+
+func TestBasicChatAPIService_ErrorHandler_notFound(test *testing.T) {
+	test.Parallel()
+	testObject := &senzingchatservice.BasicChatAPIService{
+		EntityEngine: &notFoundEntityEngine{},
+	}
+	server, err := senzingchatapi.NewServer(testObject, senzingchatapi.WithErrorHandler(testObject.ErrorHandler))
+	require.NoError(test, err)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequestWithContext(test.Context(), http.MethodGet,
+		"/entity_details?entity_id=1", nil))
+	require.Equal(test, http.StatusNotFound, recorder.Code)
+	require.Contains(test, recorder.Body.String(), "senzing-66203003")
+}
+
+func TestMessageID(test *testing.T) {
+	test.Parallel()
+	require.Equal(test, "senzing-66204001", senzingchatservice.MessageID(senzingchatservice.MessageRequestFailed))
+}
+
 // ----------------------------------------------------------------------------
 // Internal functions
 // ----------------------------------------------------------------------------
 
-// func getTestObject(ctx context.Context, test *testing.T) ChatAPIService {
-// 	_ = ctx
-// 	if chatAPIServiceSingleton == nil {
-// 		senzingEngineConfigurationJSON, err := settings.BuildSimpleSettingsUsingEnvVars()
-// 		require.NoError(test, err)
-// 		chatAPIServiceSingleton = &BasicChatAPIService{
-// 			Settings:              senzingEngineConfigurationJSON,
-// 			SenzingInstanceName:   "go-rest-api-service-test",
-// 			SenzingVerboseLogging: 0,
-// 		}
-// 	}
-// 	return chatAPIServiceSingleton
-// }
+func getTestObject(test *testing.T) *senzingchatservice.BasicChatAPIService {
+	test.Helper()
+
+	return &senzingchatservice.BasicChatAPIService{
+		EntityEngine: &mockEntityEngine{},
+	}
+}
 
 // ----------------------------------------------------------------------------
-// Test interface functions
+// Mock EntityEngine
 // ----------------------------------------------------------------------------
 
-func TestBasicChatAPIService_AddPet(test *testing.T) {
-	test.Parallel()
+type mockEntityEngine struct{}
+
+func (engine *mockEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return `{"RESOLVED_ENTITY": {"ENTITY_ID": 1, "ENTITY_NAME": "Robert Smith"}}`, nil
+}
+
+func (engine *mockEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return howJSON, nil
+}
+
+func (engine *mockEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	_ = ctx
+	_ = attributes
+
+	return searchJSON, nil
+}
+
+// ----------------------------------------------------------------------------
+// Failing EntityEngines
+// ----------------------------------------------------------------------------
+
+var errEngine = errors.New("engine detail")
+
+type failingEntityEngine struct {
+	mockEntityEngine
+}
+
+func (engine *failingEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+	_ = entityID
+
+	return "", errEngine
+}
+
+type forbiddenEntityEngine struct {
+	mockEntityEngine
+}
+
+func (engine *forbiddenEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	return "", wraperror.Errorf(senzingchatservice.ErrForbidden, "entity %d", entityID)
+}
+
+type notFoundEntityEngine struct {
+	mockEntityEngine
+}
+
+func (engine *notFoundEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	_ = ctx
+
+	return "", wraperror.Errorf(senzingchatservice.ErrNotFound, "entity %d", entityID)
 }
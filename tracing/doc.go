@@ -0,0 +1,8 @@
+/*
+Package tracing exports OpenTelemetry traces of serve-chat.
+
+NewTracerProvider() sends spans to an OTLP collector, to standard output or to a file.
+The ogen generated server starts a span per request, and TracedEntityEngine adds a child span per
+Senzing call.
+*/
+package tracing
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Options configure NewTracerProvider.
+type Options struct {
+	Exporter     string // One of the Exporter* constants.  Empty means ExporterNone.
+	File         string // Path written by ExporterFile.
+	OTLPEndpoint string // host:port of an OTLP/gRPC collector.  Empty uses the OTEL_EXPORTER_OTLP_* environment variables.
+	OTLPInsecure bool   // Connect to OTLPEndpoint without TLS.
+	ServiceName  string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Instrumentation scope of the spans.
+const TracerName = "github.com/senzing-garage/serve-chat"
+
+// Values of Options.Exporter.
+const (
+	ExporterFile   = "file"
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Prefix of the names of Senzing call spans, e.g. "senzing.SearchByAttributes".
+const SpanSenzing = "senzing."
+
+// Attribute keys.
+const (
+	AttributeEntityID  = "senzing.entity_id"
+	AttributeOperation = "senzing.operation"
+)
+
+const filePerm = 0o600
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errForPackage = errors.New("tracing")
@@ -0,0 +1,214 @@
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+	"github.com/senzing-garage/serve-chat/recording"
+	"github.com/senzing-garage/serve-chat/senzingchatservice"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// TracedEntityEngine passes calls to EntityEngine, each in a child span of the request.
+type TracedEntityEngine struct {
+	EntityEngine   senzingchatservice.EntityEngine
+	TracerProvider trace.TracerProvider
+}
+
+// closingExporter closes the file of ExporterFile after the last spans are written.
+type closingExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// GetEntityByEntityID calls the wrapped EntityEngine in a span.
+func (engine *TracedEntityEngine) GetEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	ctx, span := engine.start(ctx, recording.OperationGetEntityByEntityID, attribute.Int64(AttributeEntityID, entityID))
+	defer span.End()
+
+	result, err := engine.EntityEngine.GetEntityByEntityID(ctx, entityID)
+	RecordError(span, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// HowEntityByEntityID calls the wrapped EntityEngine in a span.
+func (engine *TracedEntityEngine) HowEntityByEntityID(ctx context.Context, entityID int64) (string, error) {
+	ctx, span := engine.start(ctx, recording.OperationHowEntityByEntityID, attribute.Int64(AttributeEntityID, entityID))
+	defer span.End()
+
+	result, err := engine.EntityEngine.HowEntityByEntityID(ctx, entityID)
+	RecordError(span, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// SearchByAttributes calls the wrapped EntityEngine in a span.
+// The attributes are personal data, so they are not added to the span.
+func (engine *TracedEntityEngine) SearchByAttributes(ctx context.Context, attributes string) (string, error) {
+	ctx, span := engine.start(ctx, recording.OperationSearchByAttributes)
+	defer span.End()
+
+	result, err := engine.EntityEngine.SearchByAttributes(ctx, attributes)
+	RecordError(span, err)
+
+	return result, err //nolint:wrapcheck
+}
+
+// Shutdown flushes the exporter, then closes its file.
+func (exporter *closingExporter) Shutdown(ctx context.Context) error {
+	err := exporter.SpanExporter.Shutdown(ctx)
+	if closeErr := exporter.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return wraperror.Errorf(err, "Shutdown")
+}
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewTracerProvider function creates a TracerProvider that exports spans as configured.
+
+Input
+  - ctx: A context to control lifecycle.
+  - options: The exporter and its settings.
+
+Output
+  - The TracerProvider, or nil for ExporterNone.  Call Shutdown() when done, to flush spans.
+*/
+func NewTracerProvider(ctx context.Context, options Options) (*sdktrace.TracerProvider, error) {
+	var (
+		err      error
+		exporter sdktrace.SpanExporter
+	)
+
+	switch options.Exporter {
+	case "", ExporterNone:
+		return nil, nil //nolint:nilnil
+	case ExporterFile:
+		file, err := os.OpenFile(options.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerm)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "os.OpenFile: %s", options.File)
+		}
+
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+
+			return nil, wraperror.Errorf(err, "stdouttrace.New")
+		}
+
+		exporter = &closingExporter{SpanExporter: exporter, file: file}
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx, otlpOptions(options)...)
+		if err != nil {
+			return nil, wraperror.Errorf(err, "otlptracegrpc.New")
+		}
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, wraperror.Errorf(err, "stdouttrace.New")
+		}
+	default:
+		return nil, wraperror.Errorf(errForPackage, "unknown trace exporter: %s", options.Exporter)
+	}
+
+	serviceName := options.ServiceName
+	if len(serviceName) == 0 {
+		serviceName = "serve-chat"
+	}
+
+	traceResource, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		traceResource = resource.Default()
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(traceResource),
+	), nil
+}
+
+/*
+The NewPropagator function returns the W3C trace context and baggage propagator used for
+incoming HTTP requests and outgoing gRPC calls.
+
+Output
+  - The propagator.
+*/
+func NewPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+/*
+The RecordError function marks a span as failed.  A nil error is ignored.
+
+Input
+  - span: The span to mark.
+  - err: The error, if any.
+*/
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (engine *TracedEntityEngine) start(
+	ctx context.Context,
+	operation string,
+	attributes ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	attributes = append(attributes, attribute.String(AttributeOperation, operation))
+
+	return engine.TracerProvider.Tracer(TracerName).Start(ctx, SpanSenzing+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func otlpOptions(options Options) []otlptracegrpc.Option {
+	var result []otlptracegrpc.Option
+
+	if len(options.OTLPEndpoint) > 0 {
+		result = append(result, otlptracegrpc.WithEndpoint(options.OTLPEndpoint))
+	}
+
+	if options.OTLPInsecure {
+		result = append(result, otlptracegrpc.WithInsecure())
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/internal/enginetest"
+	"github.com/senzing-garage/serve-chat/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var errEngine = errors.New("engine failed")
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestTracedEntityEngine_GetEntityByEntityID(test *testing.T) {
+	test.Parallel()
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tracerProvider.Tracer("test").Start(test.Context(), "request")
+	engine := &tracing.TracedEntityEngine{
+		EntityEngine:   &enginetest.EntityEngine{Err: errEngine}, //nolint:exhaustruct
+		TracerProvider: tracerProvider,
+	}
+	_, err := engine.GetEntityByEntityID(ctx, 1001)
+	require.ErrorIs(test, err, errEngine)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(test, spans, 2)
+	require.Equal(test, tracing.SpanSenzing+"GetEntityByEntityID", spans[0].Name())
+	require.Equal(test, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(test, codes.Error, spans[0].Status().Code)
+}
+
+func TestNewTracerProvider_file(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	file := filepath.Join(test.TempDir(), "traces.jsonl")
+	tracerProvider, err := tracing.NewTracerProvider(ctx, tracing.Options{ //nolint:exhaustruct
+		Exporter: tracing.ExporterFile,
+		File:     file,
+	})
+	require.NoError(test, err)
+
+	_, span := tracerProvider.Tracer("test").Start(ctx, "request")
+	span.End()
+	require.NoError(test, tracerProvider.Shutdown(context.Background()))
+
+	contents, err := os.ReadFile(file)
+	require.NoError(test, err)
+	require.Contains(test, string(contents), `"Name":"request"`)
+}
+
+func TestNewTracerProvider_none(test *testing.T) {
+	test.Parallel()
+	tracerProvider, err := tracing.NewTracerProvider(test.Context(), tracing.Options{}) //nolint:exhaustruct
+	require.NoError(test, err)
+	require.Nil(test, tracerProvider)
+}
+
+func TestNewTracerProvider_unknownExporter(test *testing.T) {
+	test.Parallel()
+	_, err := tracing.NewTracerProvider(test.Context(), tracing.Options{Exporter: "zipkin"}) //nolint:exhaustruct
+	require.Error(test, err)
+}
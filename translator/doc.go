@@ -0,0 +1,7 @@
+/*
+Package translator turns Senzing match keys, match levels, and resolution rule (ERRULE) codes
+into sentences an end user can read.
+
+For example, the match key "+NAME+DOB-SSN" becomes "same name and date of birth, but different SSN".
+*/
+package translator
@@ -0,0 +1,75 @@
+package translator
+
+import (
+	"context"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The Translator interface turns Senzing codes into plain English.
+type Translator interface {
+	Errule(erruleCode string) string
+	ExplainHow(ctx context.Context, howJSON string) (string, error)
+	ExplainSearch(ctx context.Context, searchJSON string) (string, error)
+	MatchKey(matchKey string) string
+	MatchLevel(matchLevelCode string) string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// FeatureNames maps Senzing feature types, as seen in match keys, to plain English.
+var FeatureNames = map[string]string{
+	"ACCT_NUM":          "account number",
+	"ADDRESS":           "address",
+	"CITIZENSHIP":       "citizenship",
+	"DOB":               "date of birth",
+	"DRLIC":             "driver's license",
+	"DUNS_NUMBER":       "DUNS number",
+	"EMAIL":             "email address",
+	"GENDER":            "gender",
+	"GROUP_ASSOCIATION": "group association",
+	"LEI_NUMBER":        "LEI number",
+	"NAME":              "name",
+	"NATIONAL_ID":       "national ID",
+	"NATIONALITY":       "nationality",
+	"NPI_NUMBER":        "NPI number",
+	"OTHER_ID":          "other identifier",
+	"PASSPORT":          "passport",
+	"PHONE":             "phone number",
+	"PLACE_OF_BIRTH":    "place of birth",
+	"REL_ANCHOR":        "relationship anchor",
+	"REL_POINTER":       "disclosed relationship",
+	"SSN":               "SSN",
+	"SURNAME":           "surname",
+	"TAX_ID":            "tax ID",
+	"WEBSITE":           "website",
+}
+
+// MatchLevelCodes maps Senzing MATCH_LEVEL_CODE values to plain English.
+var MatchLevelCodes = map[string]string{
+	"DISCLOSED":        "a disclosed relationship",
+	"NAME_ONLY":        "a name-only match",
+	"POSSIBLY_RELATED": "possibly related",
+	"POSSIBLY_SAME":    "a possible match",
+	"RESOLVED":         "a match",
+}
+
+// PrincipleTerms maps the underscore-separated terms of an ERRULE code to plain English.
+var PrincipleTerms = map[string]string{
+	"CEXCL":        "no conflicting exclusive identifiers",
+	"CFF":          "close supporting features",
+	"CNAME":        "close name",
+	"CSTAB":        "consistent stable identifiers",
+	"DEXCL":        "different exclusive identifiers",
+	"EXACTLY_SAME": "identical records",
+	"MFF":          "multiple supporting features",
+	"PNAME":        "partial name",
+	"SF1":          "a strong shared identifier",
+	"SFF":          "some supporting features",
+	"SNAME":        "similar name",
+	"SURNAME":      "same surname",
+}
@@ -0,0 +1,321 @@
+package translator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/senzing-garage/go-helpers/wraperror"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// BasicTranslator is the default implementation of the Translator interface.
+// Entries in the optional maps take precedence over the package-level maps of the same name.
+type BasicTranslator struct {
+	FeatureNames    map[string]string
+	MatchLevelCodes map[string]string
+	PrincipleTerms  map[string]string
+}
+
+type howResult struct {
+	HowResults struct {
+		ResolutionSteps []struct {
+			InboundVirtualEntityID string    `json:"INBOUND_VIRTUAL_ENTITY_ID"`
+			MatchInfo              matchInfo `json:"MATCH_INFO"`
+			ResultVirtualEntityID  string    `json:"RESULT_VIRTUAL_ENTITY_ID"`
+			Step                   int       `json:"STEP"`
+			VirtualEntity1         struct {
+				VirtualEntityID string `json:"VIRTUAL_ENTITY_ID"`
+			} `json:"VIRTUAL_ENTITY_1"`
+			VirtualEntity2 struct {
+				VirtualEntityID string `json:"VIRTUAL_ENTITY_ID"`
+			} `json:"VIRTUAL_ENTITY_2"`
+		} `json:"RESOLUTION_STEPS"`
+	} `json:"HOW_RESULTS"`
+}
+
+type matchInfo struct {
+	ErruleCode     string `json:"ERRULE_CODE"`
+	MatchKey       string `json:"MATCH_KEY"`
+	MatchLevelCode string `json:"MATCH_LEVEL_CODE"`
+}
+
+type searchResult struct {
+	ResolvedEntities []struct {
+		Entity struct {
+			ResolvedEntity struct {
+				EntityID   int64  `json:"ENTITY_ID"`
+				EntityName string `json:"ENTITY_NAME"`
+			} `json:"RESOLVED_ENTITY"`
+		} `json:"ENTITY"`
+		MatchInfo matchInfo `json:"MATCH_INFO"`
+	} `json:"RESOLVED_ENTITIES"`
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Errule method translates a resolution rule code (ERRULE_CODE) into plain English.
+Terms that are not known are returned unchanged.
+
+Input
+  - erruleCode: A code like "CNAME_CFF_CEXCL".
+
+Output
+  - A phrase like "close name, close supporting features and no conflicting exclusive identifiers".
+*/
+func (translator *BasicTranslator) Errule(erruleCode string) string {
+	if len(erruleCode) == 0 {
+		return ""
+	}
+
+	if phrase, ok := translator.lookup(translator.PrincipleTerms, PrincipleTerms, erruleCode); ok {
+		return phrase
+	}
+
+	terms := strings.Split(erruleCode, "_")
+	phrases := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		phrase, _ := translator.lookup(translator.PrincipleTerms, PrincipleTerms, term)
+		phrases = append(phrases, phrase)
+	}
+
+	return joinEnglish(phrases)
+}
+
+/*
+The ExplainHow method describes each step of a Senzing "how entity" result.
+
+Input
+  - ctx: A context to control lifecycle.
+  - howJSON: The JSON document returned by SzEngine.HowEntityByEntityID().
+
+Output
+  - One sentence per resolution step, separated by newlines.
+*/
+func (translator *BasicTranslator) ExplainHow(ctx context.Context, howJSON string) (string, error) {
+	_ = ctx
+
+	var parsed howResult
+
+	err := json.Unmarshal([]byte(howJSON), &parsed)
+	if err != nil {
+		return "", wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	steps := parsed.HowResults.ResolutionSteps
+	if len(steps) == 0 {
+		return "The entity has a single record, so no resolution steps were needed.", nil
+	}
+
+	sentences := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		sentence := fmt.Sprintf(
+			"Step %d: %s and %s were combined into %s because of %s",
+			step.Step,
+			step.VirtualEntity1.VirtualEntityID,
+			step.VirtualEntity2.VirtualEntityID,
+			step.ResultVirtualEntityID,
+			translator.MatchKey(step.MatchInfo.MatchKey),
+		)
+		sentences = append(sentences, sentence+translator.principleSuffix(step.MatchInfo.ErruleCode)+".")
+	}
+
+	return strings.Join(sentences, "\n"), nil
+}
+
+/*
+The ExplainSearch method describes why each entity in a Senzing search result matched.
+
+Input
+  - ctx: A context to control lifecycle.
+  - searchJSON: The JSON document returned by SzEngine.SearchByAttributes().
+
+Output
+  - One sentence per resolved entity, separated by newlines.
+*/
+func (translator *BasicTranslator) ExplainSearch(ctx context.Context, searchJSON string) (string, error) {
+	_ = ctx
+
+	var parsed searchResult
+
+	err := json.Unmarshal([]byte(searchJSON), &parsed)
+	if err != nil {
+		return "", wraperror.Errorf(err, "json.Unmarshal")
+	}
+
+	if len(parsed.ResolvedEntities) == 0 {
+		return "No entities matched the search.", nil
+	}
+
+	sentences := make([]string, 0, len(parsed.ResolvedEntities))
+
+	for _, resolvedEntity := range parsed.ResolvedEntities {
+		entity := resolvedEntity.Entity.ResolvedEntity
+		sentence := fmt.Sprintf(
+			"Entity %d (%s) is %s: %s",
+			entity.EntityID,
+			entity.EntityName,
+			translator.MatchLevel(resolvedEntity.MatchInfo.MatchLevelCode),
+			translator.MatchKey(resolvedEntity.MatchInfo.MatchKey),
+		)
+		sentences = append(sentences, sentence+translator.principleSuffix(resolvedEntity.MatchInfo.ErruleCode)+".")
+	}
+
+	return strings.Join(sentences, "\n"), nil
+}
+
+/*
+The MatchKey method translates a match key into plain English.
+
+Input
+  - matchKey: A match key like "+NAME+DOB-SSN".
+
+Output
+  - A phrase like "same name and date of birth, but different SSN".
+*/
+func (translator *BasicTranslator) MatchKey(matchKey string) string {
+	var same, different []string
+
+	for _, token := range splitMatchKey(matchKey) {
+		feature, qualifier, _ := strings.Cut(token[1:], "(")
+		phrase, _ := translator.lookup(translator.FeatureNames, FeatureNames, feature)
+
+		if roles := matchKeyRoles(qualifier); len(roles) > 0 {
+			phrase = fmt.Sprintf("%s (%s)", phrase, roles)
+		}
+
+		switch token[0] {
+		case '+':
+			same = append(same, phrase)
+		case '-':
+			different = append(different, phrase)
+		}
+	}
+
+	switch {
+	case len(same) > 0 && len(different) > 0:
+		return fmt.Sprintf("same %s, but different %s", joinEnglish(same), joinEnglish(different))
+	case len(same) > 0:
+		return "same " + joinEnglish(same)
+	case len(different) > 0:
+		return "different " + joinEnglish(different)
+	default:
+		return ""
+	}
+}
+
+/*
+The MatchLevel method translates a MATCH_LEVEL_CODE into plain English.
+
+Input
+  - matchLevelCode: A code like "POSSIBLY_SAME".
+
+Output
+  - A phrase like "a possible match".
+*/
+func (translator *BasicTranslator) MatchLevel(matchLevelCode string) string {
+	result, _ := translator.lookup(translator.MatchLevelCodes, MatchLevelCodes, matchLevelCode)
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (translator *BasicTranslator) lookup(override map[string]string, defaults map[string]string, key string) (
+	string,
+	bool,
+) {
+	if result, ok := override[key]; ok {
+		return result, true
+	}
+
+	if result, ok := defaults[key]; ok {
+		return result, true
+	}
+
+	return key, false
+}
+
+func (translator *BasicTranslator) principleSuffix(erruleCode string) string {
+	principle := translator.Errule(erruleCode)
+	if len(principle) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (principle: %s)", principle)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func joinEnglish(phrases []string) string {
+	switch len(phrases) {
+	case 0:
+		return ""
+	case 1:
+		return phrases[0]
+	default:
+		return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+	}
+}
+
+// Relationship features carry their roles in parentheses, e.g. "REL_POINTER(SPOUSE:)".
+func matchKeyRoles(qualifier string) string {
+	qualifier = strings.TrimSuffix(qualifier, ")")
+
+	var roles []string
+
+	for _, role := range strings.Split(qualifier, ":") {
+		if len(role) > 0 {
+			roles = append(roles, role)
+		}
+	}
+
+	return strings.Join(roles, "/")
+}
+
+// Split "+NAME+DOB-SSN" into "+NAME", "+DOB", "-SSN", ignoring signs inside parentheses.
+func splitMatchKey(matchKey string) []string {
+	var (
+		depth  int
+		result []string
+		start  = -1
+	)
+
+	for index, character := range matchKey {
+		switch character {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '+', '-':
+			if depth > 0 {
+				continue
+			}
+
+			if start >= 0 && index > start+1 {
+				result = append(result, matchKey[start:index])
+			}
+
+			start = index
+		}
+	}
+
+	if start >= 0 && len(matchKey) > start+1 {
+		result = append(result, matchKey[start:])
+	}
+
+	return result
+}
@@ -0,0 +1,17 @@
+package translator_test
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/serve-chat/translator"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleBasicTranslator_MatchKey() {
+	testObject := &translator.BasicTranslator{}
+	fmt.Println(testObject.MatchKey("+NAME+DOB-SSN"))
+	// Output: same name and date of birth, but different SSN
+}
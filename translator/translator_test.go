@@ -0,0 +1,107 @@
+package translator_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/serve-chat/translator"
+	"github.com/stretchr/testify/require"
+)
+
+const howJSON = `{"HOW_RESULTS": {"RESOLUTION_STEPS": [{"STEP": 1,
+	"VIRTUAL_ENTITY_1": {"VIRTUAL_ENTITY_ID": "V1"}, "VIRTUAL_ENTITY_2": {"VIRTUAL_ENTITY_ID": "V2"},
+	"INBOUND_VIRTUAL_ENTITY_ID": "V2", "RESULT_VIRTUAL_ENTITY_ID": "V1-S1",
+	"MATCH_INFO": {"MATCH_KEY": "+NAME+PHONE", "ERRULE_CODE": "CNAME_CFF"}}]}}`
+
+const searchJSON = `{"RESOLVED_ENTITIES": [{
+	"MATCH_INFO": {"MATCH_LEVEL_CODE": "POSSIBLY_SAME", "MATCH_KEY": "+NAME+DOB-SSN", "ERRULE_CODE": "SNAME_SFF"},
+	"ENTITY": {"RESOLVED_ENTITY": {"ENTITY_ID": 100001, "ENTITY_NAME": "Robert Smith"}}}]}`
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicTranslator_Errule(test *testing.T) {
+	test.Parallel()
+	testObject := &translator.BasicTranslator{}
+	require.Equal(test, "close name and close supporting features", testObject.Errule("CNAME_CFF"))
+	require.Equal(test, "identical records", testObject.Errule("EXACTLY_SAME"))
+	require.Equal(test, "close name and XYZZY", testObject.Errule("CNAME_XYZZY"))
+	require.Empty(test, testObject.Errule(""))
+}
+
+func TestBasicTranslator_ExplainHow(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &translator.BasicTranslator{}
+	actual, err := testObject.ExplainHow(ctx, howJSON)
+	require.NoError(test, err)
+	require.Equal(
+		test,
+		"Step 1: V1 and V2 were combined into V1-S1 because of same name and phone number"+
+			" (principle: close name and close supporting features).",
+		actual,
+	)
+}
+
+func TestBasicTranslator_ExplainHow_badJSON(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &translator.BasicTranslator{}
+	_, err := testObject.ExplainHow(ctx, "}{")
+	require.Error(test, err)
+}
+
+func TestBasicTranslator_ExplainSearch(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &translator.BasicTranslator{}
+	actual, err := testObject.ExplainSearch(ctx, searchJSON)
+	require.NoError(test, err)
+	require.Equal(
+		test,
+		"Entity 100001 (Robert Smith) is a possible match: same name and date of birth, but different SSN"+
+			" (principle: similar name and some supporting features).",
+		actual,
+	)
+}
+
+func TestBasicTranslator_ExplainSearch_noResults(test *testing.T) {
+	test.Parallel()
+	ctx := test.Context()
+	testObject := &translator.BasicTranslator{}
+	actual, err := testObject.ExplainSearch(ctx, `{"RESOLVED_ENTITIES": []}`)
+	require.NoError(test, err)
+	require.Equal(test, "No entities matched the search.", actual)
+}
+
+func TestBasicTranslator_MatchKey(test *testing.T) {
+	test.Parallel()
+	testObject := &translator.BasicTranslator{}
+	testCases := map[string]string{
+		"+NAME+DOB-SSN":                 "same name and date of birth, but different SSN",
+		"+NAME+DOB+ADDRESS":             "same name, date of birth and address",
+		"-SSN":                          "different SSN",
+		"+ADDRESS+REL_POINTER(SPOUSE:)": "same address and disclosed relationship (SPOUSE)",
+		"+NAME+UNKNOWN_FEATURE":         "same name and UNKNOWN_FEATURE",
+		"":                              "",
+	}
+
+	for matchKey, expected := range testCases {
+		require.Equal(test, expected, testObject.MatchKey(matchKey), matchKey)
+	}
+}
+
+func TestBasicTranslator_MatchKey_override(test *testing.T) {
+	test.Parallel()
+	testObject := &translator.BasicTranslator{
+		FeatureNames: map[string]string{"DOB": "birthday"},
+	}
+	require.Equal(test, "same name and birthday", testObject.MatchKey("+NAME+DOB"))
+}
+
+func TestBasicTranslator_MatchLevel(test *testing.T) {
+	test.Parallel()
+	testObject := &translator.BasicTranslator{}
+	require.Equal(test, "possibly related", testObject.MatchLevel("POSSIBLY_RELATED"))
+	require.Equal(test, "SOMETHING_NEW", testObject.MatchLevel("SOMETHING_NEW"))
+}